@@ -0,0 +1,21 @@
+# Look up an action owned by another team, to notify it from an alert
+data "humio_action" "oncall" {
+  repository = "sandbox"
+  name       = "oncall-pagerduty"
+}
+
+# All Slack actions in the repository
+data "humio_actions" "slack" {
+  repository = "sandbox"
+  type       = "SlackAction"
+}
+
+# All alerts in the repository, e.g. to audit which are disabled
+data "humio_alerts" "sandbox" {
+  repository = "sandbox"
+}
+
+output "disabled_alerts" {
+  value       = [for a in data.humio_alerts.sandbox.alerts : a.name if !a.enabled]
+  description = "The names of the disabled alerts in the sandbox repository"
+}
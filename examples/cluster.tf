@@ -0,0 +1,15 @@
+# Get information about the cluster the provider is connected to
+data "humio_cluster" "current" {}
+
+# Refuse to apply against a cluster that reports itself as unhealthy
+check "cluster_health" {
+  assert {
+    condition     = data.humio_cluster.current.health_status == "OK"
+    error_message = "LogScale cluster is ${data.humio_cluster.current.health_status}: ${data.humio_cluster.current.health_status_message}"
+  }
+}
+
+output "cluster_version" {
+  description = "LogScale version running on the cluster"
+  value       = data.humio_cluster.current.version
+}
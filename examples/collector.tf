@@ -0,0 +1,23 @@
+resource "humio_collector_configuration" "syslog" {
+  name        = "syslog"
+  description = "Ships the local syslog to LogScale"
+  yaml        = <<-EOT
+    sources:
+      syslog:
+        type: syslog
+        mode: udp
+        port: 514
+        sink: humio
+    sinks:
+      humio:
+        type: humio
+        token: $${INGEST_TOKEN}
+        url: https://cloud.humio.com
+  EOT
+}
+
+resource "humio_collector_group" "linux" {
+  name              = "linux"
+  filter            = "system.os = \"Linux\""
+  configuration_ids = [humio_collector_configuration.syslog.id]
+}
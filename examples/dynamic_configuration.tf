@@ -0,0 +1,9 @@
+resource "humio_dynamic_configuration" "max_ingest_request_size" {
+  key   = "MaxIngestRequestSize"
+  value = "33554432"
+}
+
+resource "humio_dynamic_configuration" "query_coordinator_max_heap_fraction" {
+  key   = "QueryCoordinatorMaxHeapFraction"
+  value = "0.4"
+}
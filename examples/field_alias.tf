@@ -0,0 +1,25 @@
+resource "humio_field_alias_schema" "web" {
+  name   = "web"
+  active = true
+
+  field {
+    name = "source.ip"
+  }
+  field {
+    name = "http.response.status_code"
+    type = "number"
+  }
+}
+
+resource "humio_field_alias_mapping" "nginx" {
+  schema_id = humio_field_alias_schema.web.id
+  name      = "nginx"
+  tags = {
+    "#type" = "nginx"
+  }
+  aliases = {
+    "source.ip"                 = "remote_addr"
+    "http.response.status_code" = "status"
+  }
+  original_fields_to_keep = ["status"]
+}
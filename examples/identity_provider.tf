@@ -0,0 +1,23 @@
+resource "humio_saml_identity_provider" "okta" {
+  name                       = "okta"
+  domains                    = ["example.com"]
+  sign_on_url                = "https://example.okta.com/app/humio/sso/saml"
+  idp_entity_id              = "http://www.okta.com/exk1234567890"
+  idp_certificate            = file("${path.module}/okta.pem")
+  group_membership_attribute = "groups"
+}
+
+resource "humio_oidc_identity_provider" "azure" {
+  name          = "azure"
+  domains       = ["example.org"]
+  client_id     = "00000000-0000-0000-0000-000000000000"
+  client_secret = var.azure_client_secret
+  issuer        = "https://login.microsoftonline.com/00000000-0000-0000-0000-000000000000/v2.0"
+  scopes        = ["openid", "email", "profile"]
+  groups_claim  = "groups"
+}
+
+variable "azure_client_secret" {
+  type      = string
+  sensitive = true
+}
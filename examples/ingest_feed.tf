@@ -0,0 +1,21 @@
+resource "humio_ingest_feed" "example_cloudtrail" {
+  repository    = "sandbox"
+  name          = "example_cloudtrail"
+  description   = "CloudTrail logs delivered to S3"
+  parser        = "json"
+  role_arn      = "arn:aws:iam::123456789012:role/humio-ingest-feed"
+  sqs_url       = "https://sqs.eu-west-1.amazonaws.com/123456789012/cloudtrail-notifications"
+  region        = "eu-west-1"
+  compression   = "Gzip"
+  preprocessing = "SplitAwsRecords"
+}
+
+resource "humio_ingest_feed" "example_disabled" {
+  repository = "sandbox"
+  name       = "example_disabled"
+  parser     = "kv"
+  role_arn   = "arn:aws:iam::123456789012:role/humio-ingest-feed"
+  sqs_url    = "https://sqs.eu-west-1.amazonaws.com/123456789012/app-log-notifications"
+  region     = "eu-west-1"
+  enabled    = false
+}
@@ -0,0 +1,27 @@
+resource "humio_ingest_listener" "example_syslog_udp" {
+  repository = "sandbox"
+  name       = "example_syslog_udp"
+  protocol   = "UDP"
+  port       = 5514
+  parser     = "syslog"
+}
+
+resource "humio_ingest_listener" "example_syslog_tcp" {
+  repository     = "sandbox"
+  name           = "example_syslog_tcp"
+  protocol       = "TCP"
+  port           = 6514
+  bind_interface = "10.0.0.10"
+  parser         = "syslog"
+  charset        = "ISO-8859-1"
+  vhost          = 1
+}
+
+resource "humio_ingest_listener" "example_gelf" {
+  repository = "sandbox"
+  name       = "example_gelf"
+  protocol   = "GELF_UDP"
+  port       = 12201
+  parser     = "json"
+  enabled    = false
+}
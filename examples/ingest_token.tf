@@ -22,3 +22,18 @@ output "ingest_token_with_accesslog_parser" {
   value     = humio_ingest_token.example_ingest_token_with_accesslog_parser.token
   sensitive = true
 }
+
+# Read an ingest token managed by another Terraform configuration
+data "humio_ingest_token" "shipper" {
+  repository = "sandbox"
+  name       = "example_ingest_token_with_accesslog_parser"
+}
+
+data "humio_ingest_tokens" "sandbox" {
+  repository = "sandbox"
+}
+
+output "shipper_ingest_token" {
+  value     = data.humio_ingest_token.shipper.token
+  sensitive = true
+}
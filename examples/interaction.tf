@@ -0,0 +1,51 @@
+resource "humio_interaction" "ip_lookup" {
+  view = "sandbox"
+  type = "CustomLinkInteraction"
+  name = "Look up IP"
+
+  condition {
+    field    = "client_ip"
+    operator = "Present"
+  }
+
+  url {
+    url_template = "https://ipinfo.io/{{ fields.client_ip }}"
+  }
+}
+
+resource "humio_interaction" "requests_from_ip" {
+  view           = "sandbox"
+  type           = "SearchLinkInteraction"
+  name           = "Requests from IP"
+  title_template = "Requests from {{ fields.client_ip }}"
+
+  search_link {
+    query_string = "client_ip = ?ip | groupBy(url)"
+    repository   = "sandbox"
+    arguments = {
+      ip = "{{ fields.client_ip }}"
+    }
+    use_widget_time_window = true
+  }
+}
+
+resource "humio_interaction" "host_dashboard" {
+  view = "sandbox"
+  type = "DashboardLinkInteraction"
+  name = "Host overview"
+
+  condition {
+    field    = "host"
+    operator = "StartsWith"
+    argument = "web-"
+  }
+
+  dashboard_link {
+    dashboard_name = "Host overview"
+    repository     = "sandbox"
+    parameters = {
+      host = "{{ fields.host }}"
+    }
+    open_in_new_tab = true
+  }
+}
@@ -0,0 +1,28 @@
+resource "humio_ip_filter" "office" {
+  name = "office"
+
+  rule {
+    action  = "allow"
+    address = "203.0.113.0/24"
+  }
+  rule {
+    action  = "allow"
+    address = "2001:db8::/32"
+  }
+  rule {
+    action  = "deny"
+    address = "all"
+  }
+}
+
+resource "humio_shared_dashboard_link" "wall_screen" {
+  view         = "sandbox"
+  dashboard_id = "abcdefghijklmnopqrstuvwxyz012345"
+  name         = "wall-screen"
+  ip_filter_id = humio_ip_filter.office.id
+}
+
+output "wall_screen_token" {
+  value     = humio_shared_dashboard_link.wall_screen.token
+  sensitive = true
+}
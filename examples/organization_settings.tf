@@ -0,0 +1,7 @@
+resource "humio_organization_settings" "this" {
+  personal_user_tokens_max_ttl_seconds                    = 7776000 # 90 days
+  organization_permission_tokens_allow_permission_updates = false
+  system_permission_tokens_enabled                        = false
+
+  shared_dashboards_ip_filter_id = humio_ip_filter.office.id
+}
@@ -0,0 +1,10 @@
+# Packages can be installed from a directory containing a manifest.yaml, or from
+# a zip archive of such a directory, e.g. path = "${path.module}/ops-1.0.0.zip".
+resource "humio_package" "example_from_directory" {
+  view = "sandbox"
+  path = "${path.module}/packages/ops"
+}
+
+output "example_package_version" {
+  value = humio_package.example_from_directory.version
+}
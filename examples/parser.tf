@@ -183,3 +183,43 @@ resource "humio_parser" "zookeeper" {
 regex("\\[(?<@timestamp>[^\\]]+)\\]\\s+(?<loglevel>\\S+)\\s+(\\[(?<thread>[^]]+)\\]\\:)?") | parseTimestamp(field=@timestamp, format="yyyy-MM-dd' 'HH:mm:ss,SSS", timezone="UTC") | kvParse()
 PARSERSCRIPT
 }
+
+# The test data is run through the parser script on the server during plan. Opt out when planning without access to
+# the server.
+resource "humio_parser" "offline" {
+  repository        = "sandbox"
+  name              = "offline"
+  parser_script     = "kvParse()"
+  tag_fields        = ["service"]
+  test_data         = ["service=api msg=hello"]
+  run_tests_on_plan = false
+}
+
+# Structured test cases assert on the fields the parser produces, and are checked during plan
+resource "humio_parser" "checkout" {
+  repository    = "sandbox"
+  name          = "checkout"
+  parser_script = "kvParse() | parseTimestamp(field=ts)"
+  tag_fields    = ["service"]
+
+  test_case {
+    event = "ts=2024-05-01T12:00:00Z service=checkout level=error msg=\"payment declined\""
+
+    assertions {
+      field_equals = {
+        service = "checkout"
+        level   = "error"
+      }
+      fields_absent = ["@error"]
+    }
+  }
+}
+
+# Drop fields added by the shipper before parsing, and take over a parser created by hand with the same name
+resource "humio_parser" "syslog" {
+  repository                        = "sandbox"
+  name                              = "syslog"
+  parser_script                     = "kvParse()"
+  fields_to_remove_before_parsing   = ["agent.ephemeral_id", "ecs.version"]
+  allow_overwriting_existing_parser = true
+}
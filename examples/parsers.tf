@@ -0,0 +1,15 @@
+# List all parsers in a repository, including the built-in ones
+data "humio_parsers" "sandbox" {
+  repository = "sandbox"
+}
+
+# Read a built-in parser, e.g. to check it exists before assigning it to an ingest token
+data "humio_parser" "kv" {
+  repository = "sandbox"
+  name       = "kv"
+}
+
+output "built_in_parsers" {
+  value       = [for p in data.humio_parsers.sandbox.parsers : p.name if p.is_built_in]
+  description = "The built-in parsers of the sandbox repository"
+}
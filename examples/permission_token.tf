@@ -0,0 +1,21 @@
+resource "humio_organization_permission_token" "repository_automation" {
+  name        = "repository-automation"
+  permissions = ["CreateRepository", "ViewUsage"]
+}
+
+resource "humio_view_permission_token" "dashboard_reader" {
+  name        = "dashboard-reader"
+  views       = [humio_repository.example_repo_minimal_fields_set.name]
+  permissions = ["ReadAccess"]
+  expire_at   = "2030-01-01T00:00:00Z"
+}
+
+resource "humio_system_permission_token" "health_check" {
+  name        = "health-check"
+  permissions = ["ReadHealthCheck"]
+}
+
+output "dashboard_reader_token" {
+  value     = humio_view_permission_token.dashboard_reader.token
+  sensitive = true
+}
@@ -0,0 +1,29 @@
+# Count the rows of a lookup file, for example to assert it was uploaded completely
+data "humio_query" "hosts_lookup" {
+  search_domain = "sandbox"
+  query_string  = "readFile(\"hosts.csv\") | count()"
+  start         = "1m"
+
+  timeouts {
+    read = "2m"
+  }
+}
+
+check "hosts_lookup_complete" {
+  assert {
+    condition     = tonumber(data.humio_query.hosts_lookup.events[0]["_count"]) > 0
+    error_message = "The hosts.csv lookup file is empty"
+  }
+}
+
+# Drive configuration from query results, such as the services that logged in the last day
+data "humio_query" "services" {
+  search_domain = "sandbox"
+  query_string  = "groupBy(service)"
+  start         = "24h"
+}
+
+output "services" {
+  description = "Services that have logged in the last 24 hours"
+  value       = [for event in data.humio_query.services.events : event["service"]]
+}
@@ -0,0 +1,18 @@
+resource "humio_query_quota" "default" {
+  interval            = "PerHour"
+  max_query_cost      = 1000000
+  max_live_query_cost = 500000
+}
+
+resource "humio_query_quota" "reporting" {
+  username       = "reporting-service"
+  interval       = "PerDay"
+  max_query_cost = 10000000
+}
+
+# Each interval of a quota is a resource of its own; other intervals of the same quota are left alone
+resource "humio_query_quota" "reporting_burst" {
+  username       = "reporting-service"
+  interval       = "PerTenMinutes"
+  max_query_cost = 500000
+}
@@ -0,0 +1,11 @@
+data "humio_repository" "platform_logs" {
+  name = "platform-logs"
+}
+
+data "humio_repositories" "team_payments" {
+  name_prefix = "payments-"
+}
+
+output "payments_repositories" {
+  value = data.humio_repositories.team_payments.names
+}
@@ -6,7 +6,12 @@ resource "humio_repository" "example_repo_all_fields_set" {
   name        = "example_repo_all_${local.email_prefix}"
   description = "This is an example"
 
+  deletion_protection = false
+  delete_reason       = "Example repository removed"
+
   retention {
-    time_in_days = 30
+    time_in_days       = 30
+    ingest_size_in_gb  = 100
+    storage_size_in_gb = 10
   }
 }
@@ -0,0 +1,15 @@
+resource "humio_repository" "example_archived" {
+  name = "example_repo_archived_${local.email_prefix}"
+}
+
+resource "humio_repository_s3_archiving" "example_archived" {
+  repository = humio_repository.example_archived.name
+  bucket     = "example-humio-archive"
+  region     = "eu-west-1"
+  format     = "NDJSON"
+  start_from = "2024-01-01T00:00:00Z"
+
+  # Archiving is only ever disabled by setting this to false explicitly. The
+  # resource can't be destroyed while archiving is still enabled.
+  enabled = true
+}
@@ -0,0 +1,6 @@
+resource "humio_search_domain_settings" "example" {
+  search_domain       = humio_repository.example_repo_all_fields_set.name
+  automatic_search    = false
+  default_query       = "#type=accesslog | status >= 500"
+  default_time_window = "1h"
+}
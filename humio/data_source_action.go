@@ -0,0 +1,53 @@
+package humio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func dataSourceAction() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceActionRead,
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"action_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceActionRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	repository := d.Get("repository").(string)
+	action, err := client.(*humio.Client).Actions().Get(repository, d.Get("name").(string))
+	if err != nil {
+		return diag.Errorf("could not get action: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s+%s", repository, action.ID))
+	if err := d.Set("action_id", action.ID); err != nil {
+		return diag.Errorf("error setting action_id: %s", err)
+	}
+	if err := d.Set("type", action.Type); err != nil {
+		return diag.Errorf("error setting type: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package humio
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceAction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckActionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: dataSourceActionsConfig + `
+data "humio_action" "test" {
+    repository = "sandbox"
+    name       = humio_action.slack.name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.humio_action.test", "action_id", "humio_action.slack", "action_id"),
+					resource.TestCheckResourceAttr("data.humio_action.test", "type", "SlackAction"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceActions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckActionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: dataSourceActionsConfig + `
+data "humio_actions" "by_type" {
+    repository = "sandbox"
+    type       = "EmailAction"
+    depends_on = [humio_action.email, humio_action.slack]
+}
+
+data "humio_actions" "by_name" {
+    repository = "sandbox"
+    name_regex = "^data-source-actions-"
+    depends_on = [humio_action.email, humio_action.slack]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttrPair("data.humio_actions.by_type", "action_ids.*", "humio_action.email", "action_id"),
+					resource.TestCheckResourceAttr("data.humio_actions.by_name", "actions.#", "2"),
+					resource.TestCheckResourceAttr("data.humio_actions.by_name", "actions.0.name", "data-source-actions-email"),
+					resource.TestCheckResourceAttr("data.humio_actions.by_name", "actions.1.type", "SlackAction"),
+				),
+			},
+		},
+	})
+}
+
+const dataSourceActionsConfig = `
+resource "humio_action" "email" {
+    repository = "sandbox"
+    type       = "EmailAction"
+    name       = "data-source-actions-email"
+    email {
+        recipients = ["ops@example.com"]
+    }
+}
+
+resource "humio_action" "slack" {
+    repository = "sandbox"
+    type       = "SlackAction"
+    name       = "data-source-actions-slack"
+    slack {
+        fields = {
+            "Query" = "{query_string}"
+        }
+        url = "https://hooks.slack.com/services/XXXXXXXXX/YYYYYYYYY/ZZZZZZZZZZZZZZZZZZZZZZZZ"
+    }
+}
+`
@@ -0,0 +1,105 @@
+package humio
+
+import (
+	"context"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func dataSourceActions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceActionsRead,
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// Actions must match both filters when both are given.
+			"type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(actionTypes, false)),
+			},
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+			},
+			"action_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"actions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceActionsRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	repository := d.Get("repository").(string)
+	actions, err := client.(*humio.Client).Actions().List(repository)
+	if err != nil {
+		return diag.Errorf("could not list actions: %s", err)
+	}
+	sort.Slice(actions, func(i, j int) bool {
+		return actions[i].Name < actions[j].Name
+	})
+
+	actionType := d.Get("type").(string)
+	var re *regexp.Regexp
+	if nameRegex := d.Get("name_regex").(string); nameRegex != "" {
+		re = regexp.MustCompile(nameRegex)
+	}
+
+	actionIDs := []string{}
+	matching := []tfMap{}
+	for _, action := range actions {
+		if actionType != "" && action.Type != actionType {
+			continue
+		}
+		if re != nil && !re.MatchString(action.Name) {
+			continue
+		}
+		actionIDs = append(actionIDs, action.ID)
+		matching = append(matching, tfMap{
+			"action_id": action.ID,
+			"name":      action.Name,
+			"type":      action.Type,
+		})
+	}
+
+	d.SetId(repository)
+	if err := d.Set("action_ids", actionIDs); err != nil {
+		return diag.Errorf("error setting action_ids: %s", err)
+	}
+	if err := d.Set("actions", matching); err != nil {
+		return diag.Errorf("error setting actions: %s", err)
+	}
+
+	return nil
+}
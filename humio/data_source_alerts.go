@@ -0,0 +1,97 @@
+package humio
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func dataSourceAlerts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAlertsRead,
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"alerts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"alert_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"query": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"start": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"actions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"labels": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAlertsRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	repository := d.Get("repository").(string)
+	alerts, err := client.(*humio.Client).Alerts().List(repository)
+	if err != nil {
+		return diag.Errorf("could not list alerts: %s", err)
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].Name < alerts[j].Name
+	})
+
+	list := make([]tfMap, len(alerts))
+	for i, alert := range alerts {
+		list[i] = tfMap{
+			"alert_id":    alert.ID,
+			"name":        alert.Name,
+			"description": alert.Description,
+			"enabled":     alert.Enabled,
+			"query":       alert.QueryString,
+			"start":       alert.QueryStart,
+			"actions":     alert.Actions,
+			"labels":      alert.Labels,
+		}
+	}
+
+	d.SetId(repository)
+	if err := d.Set("alerts", list); err != nil {
+		return diag.Errorf("error setting alerts: %s", err)
+	}
+
+	return nil
+}
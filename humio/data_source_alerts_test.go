@@ -0,0 +1,44 @@
+package humio
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceAlerts(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAlertDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "humio_alert" "test" {
+    repository           = "sandbox"
+    name                 = "data-source-alerts-test"
+    throttle_time_millis = 3600000
+    start                = "24h"
+    query                = "loglevel=ERROR"
+    labels               = ["audit"]
+}
+
+data "humio_alerts" "test" {
+    repository = "sandbox"
+    depends_on = [humio_alert.test]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.humio_alerts.test", "alerts.*", map[string]string{
+						"name":     "data-source-alerts-test",
+						"enabled":  "false",
+						"query":    "loglevel=ERROR",
+						"start":    "24h",
+						"labels.#": "1",
+						"labels.0": "audit",
+					}),
+				),
+			},
+		},
+	})
+}
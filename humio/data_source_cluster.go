@@ -0,0 +1,148 @@
+package humio
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func dataSourceCluster() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceClusterRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"nodes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"uri": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"zone": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"roles": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"is_available": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			// health_status is one of OK, WARN or DOWN.
+			"health_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"health_status_message": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"health_checks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status_message": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceClusterRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	cluster, err := client.(*humio.Client).Cluster().Get()
+	if err != nil {
+		return diag.Errorf("could not get cluster: %s", err)
+	}
+	health, err := client.(*humio.Client).Cluster().Health()
+	if err != nil {
+		return diag.Errorf("could not get cluster health: %s", err)
+	}
+
+	sort.Slice(cluster.Nodes, func(i, j int) bool {
+		return cluster.Nodes[i].ID < cluster.Nodes[j].ID
+	})
+	nodes := make([]tfMap, len(cluster.Nodes))
+	for i, node := range cluster.Nodes {
+		nodes[i] = tfMap{
+			"id":           node.ID,
+			"name":         node.Name,
+			"uri":          node.URI,
+			"zone":         node.Zone,
+			"version":      node.Version,
+			"roles":        node.Roles,
+			"is_available": node.IsAvailable,
+		}
+	}
+
+	checks := make([]tfMap, len(health.Checks))
+	for i, check := range health.Checks {
+		checks[i] = tfMap{
+			"name":           check.Name,
+			"status":         check.Status,
+			"status_message": check.StatusMessage,
+		}
+	}
+
+	d.SetId(cluster.ID)
+	if err := d.Set("version", cluster.Version); err != nil {
+		return diag.Errorf("error setting version: %s", err)
+	}
+	if err := d.Set("nodes", nodes); err != nil {
+		return diag.Errorf("error setting nodes: %s", err)
+	}
+	if err := d.Set("health_status", health.Status); err != nil {
+		return diag.Errorf("error setting health_status: %s", err)
+	}
+	if err := d.Set("health_status_message", health.StatusMessage); err != nil {
+		return diag.Errorf("error setting health_status_message: %s", err)
+	}
+	if err := d.Set("health_checks", checks); err != nil {
+		return diag.Errorf("error setting health_checks: %s", err)
+	}
+
+	return nil
+}
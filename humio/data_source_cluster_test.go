@@ -0,0 +1,27 @@
+package humio
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceCluster(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "humio_cluster" "test" {}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.humio_cluster.test", "id"),
+					resource.TestCheckResourceAttrSet("data.humio_cluster.test", "version"),
+					resource.TestCheckResourceAttrSet("data.humio_cluster.test", "nodes.0.name"),
+					resource.TestCheckResourceAttr("data.humio_cluster.test", "health_status", "OK"),
+				),
+			},
+		},
+	})
+}
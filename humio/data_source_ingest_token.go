@@ -0,0 +1,54 @@
+package humio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func dataSourceIngestToken() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIngestTokenRead,
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"parser": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"token": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func dataSourceIngestTokenRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	repository := d.Get("repository").(string)
+	ingestToken, err := client.(*humio.Client).IngestTokens().Get(repository, d.Get("name").(string))
+	if err != nil {
+		return diag.Errorf("could not get ingest token: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s+%s", repository, ingestToken.Name))
+	if err := d.Set("parser", ingestToken.AssignedParser); err != nil {
+		return diag.Errorf("error setting parser: %s", err)
+	}
+	if err := d.Set("token", ingestToken.Token); err != nil {
+		return diag.Errorf("error setting token: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package humio
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceIngestToken(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIngestTokenDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: dataSourceIngestTokenConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.humio_ingest_token.test", "token", "humio_ingest_token.test", "token"),
+					resource.TestCheckResourceAttr("data.humio_ingest_token.test", "parser", "kv"),
+					resource.TestCheckTypeSetElemAttr("data.humio_ingest_tokens.test", "names.*", "data-source-ingest-token-test"),
+				),
+			},
+		},
+	})
+}
+
+const dataSourceIngestTokenConfig = `
+resource "humio_ingest_token" "test" {
+    repository = "sandbox"
+    name       = "data-source-ingest-token-test"
+    parser     = "kv"
+}
+
+data "humio_ingest_token" "test" {
+    repository = humio_ingest_token.test.repository
+    name       = humio_ingest_token.test.name
+}
+
+data "humio_ingest_tokens" "test" {
+    repository = "sandbox"
+    depends_on = [humio_ingest_token.test]
+}
+`
@@ -0,0 +1,51 @@
+package humio
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+// dataSourceIngestTokens only lists the names of the ingest tokens, so the token values don't end up in the state of
+// every configuration that lists them. Use the humio_ingest_token data source to read a token value.
+func dataSourceIngestTokens() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIngestTokensRead,
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceIngestTokensRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	repository := d.Get("repository").(string)
+	ingestTokens, err := client.(*humio.Client).IngestTokens().List(repository)
+	if err != nil {
+		return diag.Errorf("could not list ingest tokens: %s", err)
+	}
+
+	names := make([]string, len(ingestTokens))
+	for i, ingestToken := range ingestTokens {
+		names[i] = ingestToken.Name
+	}
+	sort.Strings(names)
+
+	d.SetId(repository)
+	if err := d.Set("names", names); err != nil {
+		return diag.Errorf("error setting names: %s", err)
+	}
+
+	return nil
+}
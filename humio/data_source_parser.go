@@ -0,0 +1,125 @@
+package humio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func dataSourceParser() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceParserRead,
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"parser_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_built_in": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"parser_script": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tag_fields": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"fields_to_remove_before_parsing": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"test_data": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"test_case": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"event": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"assertions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"output_event_index": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"field_equals": {
+										Type:     schema.TypeMap,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+									"fields_absent": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceParserRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	repository := d.Get("repository").(string)
+	parser, err := client.(*humio.Client).Parsers().Get(repository, d.Get("name").(string))
+	if err != nil {
+		return diag.Errorf("could not get parser: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s+%s", repository, parser.Name))
+	if err := d.Set("parser_id", parser.ID); err != nil {
+		return diag.Errorf("error setting parser_id: %s", err)
+	}
+	if err := d.Set("is_built_in", parser.IsBuiltIn); err != nil {
+		return diag.Errorf("error setting is_built_in: %s", err)
+	}
+	if err := d.Set("parser_script", parser.Script); err != nil {
+		return diag.Errorf("error setting parser_script: %s", err)
+	}
+	if err := d.Set("tag_fields", parser.FieldsToTag); err != nil {
+		return diag.Errorf("error setting tag_fields: %s", err)
+	}
+	if err := d.Set("fields_to_remove_before_parsing", parser.FieldsToBeRemovedBeforeParsing); err != nil {
+		return diag.Errorf("error setting fields_to_remove_before_parsing: %s", err)
+	}
+	testData := make([]string, len(parser.TestCases))
+	for i, testCase := range parser.TestCases {
+		testData[i] = testCase.Event.RawString
+	}
+	if err := d.Set("test_data", testData); err != nil {
+		return diag.Errorf("error setting test_data: %s", err)
+	}
+	if err := d.Set("test_case", testCasesFromParser(parser)); err != nil {
+		return diag.Errorf("error setting test_case: %s", err)
+	}
+
+	return nil
+}
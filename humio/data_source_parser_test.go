@@ -0,0 +1,47 @@
+package humio
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceParser(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckParserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "humio_parser" "test" {
+    repository    = "sandbox"
+    name          = "data-source-parser-test"
+    parser_script = "kvParse()"
+    tag_fields    = ["host"]
+    test_data     = ["host=a"]
+}
+
+data "humio_parser" "custom" {
+    repository = humio_parser.test.repository
+    name       = humio_parser.test.name
+}
+
+data "humio_parser" "built_in" {
+    repository = "sandbox"
+    name       = "kv"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.humio_parser.custom", "is_built_in", "false"),
+					resource.TestCheckResourceAttr("data.humio_parser.custom", "parser_script", "kvParse()"),
+					resource.TestCheckResourceAttr("data.humio_parser.custom", "tag_fields.0", "host"),
+					resource.TestCheckResourceAttr("data.humio_parser.custom", "test_data.0", "host=a"),
+					resource.TestCheckResourceAttr("data.humio_parser.custom", "test_case.0.event", "host=a"),
+					resource.TestCheckResourceAttr("data.humio_parser.built_in", "is_built_in", "true"),
+					resource.TestCheckResourceAttrSet("data.humio_parser.built_in", "parser_script"),
+				),
+			},
+		},
+	})
+}
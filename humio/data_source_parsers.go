@@ -0,0 +1,89 @@
+package humio
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func dataSourceParsers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceParsersRead,
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"include_built_in": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"parsers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_built_in": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceParsersRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	repository := d.Get("repository").(string)
+	parsers, err := client.(*humio.Client).Parsers().ListAll(repository)
+	if err != nil {
+		return diag.Errorf("could not list parsers: %s", err)
+	}
+	sort.Slice(parsers, func(i, j int) bool {
+		return parsers[i].Name < parsers[j].Name
+	})
+
+	includeBuiltIn := d.Get("include_built_in").(bool)
+	names := []string{}
+	matching := []tfMap{}
+	for _, parser := range parsers {
+		if parser.IsBuiltIn && !includeBuiltIn {
+			continue
+		}
+		names = append(names, parser.Name)
+		matching = append(matching, tfMap{
+			"id":          parser.ID,
+			"name":        parser.Name,
+			"is_built_in": parser.IsBuiltIn,
+		})
+	}
+
+	d.SetId(repository)
+	if err := d.Set("names", names); err != nil {
+		return diag.Errorf("error setting names: %s", err)
+	}
+	if err := d.Set("parsers", matching); err != nil {
+		return diag.Errorf("error setting parsers: %s", err)
+	}
+
+	return nil
+}
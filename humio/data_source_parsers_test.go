@@ -0,0 +1,46 @@
+package humio
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceParsers(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckParserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "humio_parser" "test" {
+    repository = "sandbox"
+    name       = "data-source-parsers-test"
+}
+
+data "humio_parsers" "all" {
+    repository = "sandbox"
+    depends_on = [humio_parser.test]
+}
+
+data "humio_parsers" "custom" {
+    repository       = "sandbox"
+    include_built_in = false
+    depends_on       = [humio_parser.test]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.humio_parsers.all", "names.*", "kv"),
+					resource.TestCheckTypeSetElemAttr("data.humio_parsers.all", "names.*", "data-source-parsers-test"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.humio_parsers.all", "parsers.*", map[string]string{
+						"name":        "json",
+						"is_built_in": "true",
+					}),
+					resource.TestCheckResourceAttr("data.humio_parsers.custom", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.humio_parsers.custom", "names.0", "data-source-parsers-test"),
+				),
+			},
+		},
+	})
+}
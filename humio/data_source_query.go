@@ -0,0 +1,130 @@
+package humio
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+// dataSourceQuery runs a static search every time it is read, so its results reflect the data at plan time.
+func dataSourceQuery() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceQueryRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"search_domain": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			},
+			"query_string": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"start": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "24h",
+			},
+			// Leaving out end searches up to now.
+			"end": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{Type: schema.TypeString},
+				},
+			},
+			"event_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			// The counts and times below are numbers rather than ints, as they overflow the int of 32-bit platforms.
+			"processed_events": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"processed_bytes": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"field_order": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// query_start and query_end are in milliseconds since the epoch.
+			"query_start": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"query_end": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceQueryRead(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	searchDomain := d.Get("search_domain").(string)
+	input := humio.QueryJobInput{
+		QueryString: d.Get("query_string").(string),
+		Start:       d.Get("start").(string),
+		End:         d.Get("end").(string),
+	}
+	result, err := client.(*humio.Client).QueryJobs().Run(ctx, searchDomain, input)
+	if err != nil {
+		return diag.Errorf("could not run query: %s", err)
+	}
+
+	events := make([]interface{}, len(result.Events))
+	for i, event := range result.Events {
+		fields := make(tfMap, len(event))
+		for name, value := range event {
+			fields[name] = value
+		}
+		events[i] = fields
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join([]string{searchDomain, input.QueryString, input.Start, input.End}, "+"))))
+	if err := d.Set("events", events); err != nil {
+		return diag.Errorf("error setting events: %s", err)
+	}
+	if err := d.Set("event_count", int(result.EventCount)); err != nil {
+		return diag.Errorf("error setting event_count: %s", err)
+	}
+	if err := d.Set("processed_events", float64(result.ProcessedEvents)); err != nil {
+		return diag.Errorf("error setting processed_events: %s", err)
+	}
+	if err := d.Set("processed_bytes", float64(result.ProcessedBytes)); err != nil {
+		return diag.Errorf("error setting processed_bytes: %s", err)
+	}
+	if err := d.Set("field_order", result.FieldOrder); err != nil {
+		return diag.Errorf("error setting field_order: %s", err)
+	}
+	if err := d.Set("query_start", float64(result.QueryStart)); err != nil {
+		return diag.Errorf("error setting query_start: %s", err)
+	}
+	if err := d.Set("query_end", float64(result.QueryEnd)); err != nil {
+		return diag.Errorf("error setting query_end: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package humio
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceQuery(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "humio_query" "test" {
+    search_domain = "sandbox"
+    query_string  = "count() | eval(answer = 42)"
+    start         = "1h"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.humio_query.test", "events.#", "1"),
+					resource.TestCheckResourceAttr("data.humio_query.test", "events.0.answer", "42"),
+					resource.TestCheckResourceAttrSet("data.humio_query.test", "events.0._count"),
+					resource.TestCheckResourceAttr("data.humio_query.test", "event_count", "1"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,103 @@
+package humio
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func dataSourceRepositories() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRepositoriesRead,
+		Schema: map[string]*schema.Schema{
+			// Repositories must match both filters when both are given.
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+			},
+			"name_prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"repositories": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRepositoriesRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	repositories, err := client.(*humio.Client).Repositories().List()
+	if err != nil {
+		return diag.Errorf("could not list repositories: %s", err)
+	}
+
+	sort.Slice(repositories, func(i, j int) bool {
+		return repositories[i].Name < repositories[j].Name
+	})
+
+	nameRegex := d.Get("name_regex").(string)
+	namePrefix := d.Get("name_prefix").(string)
+	var re *regexp.Regexp
+	if nameRegex != "" {
+		re = regexp.MustCompile(nameRegex)
+	}
+
+	names := []string{}
+	matching := []tfMap{}
+	for _, repository := range repositories {
+		if !strings.HasPrefix(repository.Name, namePrefix) {
+			continue
+		}
+		if re != nil && !re.MatchString(repository.Name) {
+			continue
+		}
+		names = append(names, repository.Name)
+		matching = append(matching, tfMap{
+			"id":          repository.ID,
+			"name":        repository.Name,
+			"description": repository.Description,
+		})
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(namePrefix + "+" + nameRegex)))
+	if err := d.Set("names", names); err != nil {
+		return diag.Errorf("error setting names: %s", err)
+	}
+	if err := d.Set("repositories", matching); err != nil {
+		return diag.Errorf("error setting repositories: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package humio
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRepositories(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRepositoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "humio_repository" "a" {
+    name                = "data-source-repositories-a"
+    deletion_protection = false
+}
+
+resource "humio_repository" "b" {
+    name                = "data-source-repositories-b"
+    deletion_protection = false
+}
+
+data "humio_repositories" "prefix" {
+    name_prefix = "data-source-repositories-"
+    depends_on  = [humio_repository.a, humio_repository.b]
+}
+
+data "humio_repositories" "regex" {
+    name_regex = "^data-source-repositories-[b-z]$"
+    depends_on = [humio_repository.a, humio_repository.b]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.humio_repositories.prefix", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.humio_repositories.prefix", "names.0", "data-source-repositories-a"),
+					resource.TestCheckResourceAttr("data.humio_repositories.prefix", "names.1", "data-source-repositories-b"),
+					resource.TestCheckResourceAttr("data.humio_repositories.regex", "names.#", "1"),
+					resource.TestCheckResourceAttrPair("data.humio_repositories.regex", "repositories.0.name", "humio_repository.b", "name"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,67 @@
+package humio
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func dataSourceRepository() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRepositoryRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"retention": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"time_in_days": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"ingest_size_in_gb": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"storage_size_in_gb": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRepositoryRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	repository, err := client.(*humio.Client).Repositories().Get(d.Get("name").(string))
+	if err != nil {
+		return diag.Errorf("could not get repository: %s", err)
+	}
+
+	d.SetId(repository.ID)
+	if err := d.Set("description", repository.Description); err != nil {
+		return diag.Errorf("error setting description: %s", err)
+	}
+	if err := d.Set("retention", retentionFromRepository(&repository)); err != nil {
+		return diag.Errorf("error setting retention: %s", err)
+	}
+
+	return nil
+}
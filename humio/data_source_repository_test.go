@@ -0,0 +1,39 @@
+package humio
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRepository(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRepositoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "humio_repository" "test" {
+    name                = "data-source-repository-test"
+    description         = "Read by a data source"
+    deletion_protection = false
+    retention {
+        time_in_days = 30
+    }
+}
+
+data "humio_repository" "test" {
+    name = humio_repository.test.name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.humio_repository.test", "name", "humio_repository.test", "name"),
+					resource.TestCheckResourceAttrSet("data.humio_repository.test", "id"),
+					resource.TestCheckResourceAttr("data.humio_repository.test", "description", "Read by a data source"),
+					resource.TestCheckResourceAttr("data.humio_repository.test", "retention.0.time_in_days", "30"),
+				),
+			},
+		},
+	})
+}
@@ -59,14 +59,45 @@ func Provider() *schema.Provider {
 			}), diagnostics
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"humio_alert":        resourceAlert(),
-			"humio_ingest_token": resourceIngestToken(),
-			"humio_action":       resourceAction(),
-			"humio_parser":       resourceParser(),
-			"humio_repository":   resourceRepository(),
+			"humio_alert":                         resourceAlert(),
+			"humio_collector_configuration":       resourceCollectorConfiguration(),
+			"humio_collector_group":               resourceCollectorGroup(),
+			"humio_dynamic_configuration":         resourceDynamicConfiguration(),
+			"humio_field_alias_mapping":           resourceFieldAliasMapping(),
+			"humio_field_alias_schema":            resourceFieldAliasSchema(),
+			"humio_ingest_feed":                   resourceIngestFeed(),
+			"humio_ingest_listener":               resourceIngestListener(),
+			"humio_ingest_token":                  resourceIngestToken(),
+			"humio_action":                        resourceAction(),
+			"humio_interaction":                   resourceInteraction(),
+			"humio_ip_filter":                     resourceIPFilter(),
+			"humio_oidc_identity_provider":        resourceOIDCIdentityProvider(),
+			"humio_organization_permission_token": resourceOrganizationPermissionToken(),
+			"humio_organization_settings":         resourceOrganizationSettings(),
+			"humio_package":                       resourcePackage(),
+			"humio_parser":                        resourceParser(),
+			"humio_query_quota":                   resourceQueryQuota(),
+			"humio_repository":                    resourceRepository(),
+			"humio_repository_s3_archiving":       resourceRepositoryS3Archiving(),
+			"humio_saml_identity_provider":        resourceSAMLIdentityProvider(),
+			"humio_search_domain_settings":        resourceSearchDomainSettings(),
+			"humio_shared_dashboard_link":         resourceSharedDashboardLink(),
+			"humio_system_permission_token":       resourceSystemPermissionToken(),
+			"humio_view_permission_token":         resourceViewPermissionToken(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"humio_user": dataSourceUser(),
+			"humio_action":        dataSourceAction(),
+			"humio_actions":       dataSourceActions(),
+			"humio_alerts":        dataSourceAlerts(),
+			"humio_cluster":       dataSourceCluster(),
+			"humio_ingest_token":  dataSourceIngestToken(),
+			"humio_ingest_tokens": dataSourceIngestTokens(),
+			"humio_parser":        dataSourceParser(),
+			"humio_parsers":       dataSourceParsers(),
+			"humio_query":         dataSourceQuery(),
+			"humio_repositories":  dataSourceRepositories(),
+			"humio_repository":    dataSourceRepository(),
+			"humio_user":          dataSourceUser(),
 		},
 		Schema: map[string]*schema.Schema{
 			"addr": {
@@ -31,6 +31,18 @@ import (
 
 var rxEmail = regexp.MustCompile("^[a-zA-Z0-9.!#$%&'*+\\/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$")
 
+// actionTypes lists the types of actions supported by the provider.
+var actionTypes = []string{
+	humio.ActionTypeEmail,
+	humio.ActionTypeHumioRepo,
+	humio.ActionTypeOpsGenie,
+	humio.ActionTypePagerDuty,
+	humio.ActionTypeSlack,
+	humio.ActionTypeSlackPostMessage,
+	humio.ActionTypeVictorOps,
+	humio.ActionTypeWebhook,
+}
+
 func resourceAction() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceActionCreate,
@@ -51,19 +63,10 @@ func resourceAction() *schema.Resource {
 				Required: true,
 			},
 			"type": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
-					humio.ActionTypeEmail,
-					humio.ActionTypeHumioRepo,
-					humio.ActionTypeOpsGenie,
-					humio.ActionTypePagerDuty,
-					humio.ActionTypeSlack,
-					humio.ActionTypeSlackPostMessage,
-					humio.ActionTypeVictorOps,
-					humio.ActionTypeWebhook,
-				}, false)),
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(actionTypes, false)),
 			},
 			"name": {
 				Type:     schema.TypeString,
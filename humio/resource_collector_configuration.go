@@ -0,0 +1,180 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"gopkg.in/yaml.v3"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func resourceCollectorConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCollectorConfigurationCreate,
+		ReadContext:   resourceCollectorConfigurationRead,
+		UpdateContext: resourceCollectorConfigurationUpdate,
+		DeleteContext: resourceCollectorConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			// The YAML is kept as written, so plans show it line by line, while reformatting it without changing its
+			// meaning doesn't cause a diff.
+			"yaml": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateCollectorConfigurationYAML),
+				DiffSuppressFunc: suppressEquivalentYAML,
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// validateCollectorConfigurationYAML checks that the configuration is a YAML mapping, as the LogCollector expects.
+func validateCollectorConfigurationYAML(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	var config map[string]interface{}
+	if err := yaml.Unmarshal([]byte(v), &config); err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a YAML mapping: %s", k, err)}
+	}
+	if config == nil {
+		return nil, []error{fmt.Errorf("expected %s to be a YAML mapping, got an empty document", k)}
+	}
+	return nil, nil
+}
+
+// suppressEquivalentYAML suppresses diffs between YAML documents with the same content, e.g. differing only in
+// indentation, quoting or comments.
+func suppressEquivalentYAML(_, old, new string, _ *schema.ResourceData) bool {
+	var oldYAML, newYAML interface{}
+	if err := yaml.Unmarshal([]byte(old), &oldYAML); err != nil {
+		return false
+	}
+	if err := yaml.Unmarshal([]byte(new), &newYAML); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldYAML, newYAML)
+}
+
+func resourceCollectorConfigurationCreate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	configuration, err := collectorConfigurationFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain collector configuration from resource data: %s", err)
+	}
+
+	c, err := client.(*humio.Client).Collectors().AddConfiguration(
+		&configuration,
+	)
+	// A configuration created before a later step failed is kept in the state, so Terraform taints it rather than
+	// losing track of it.
+	if c != nil && c.ID != "" {
+		d.SetId(c.ID)
+	}
+	if err != nil {
+		return diag.Errorf("could not create collector configuration: %s", err)
+	}
+
+	return resourceCollectorConfigurationRead(ctx, d, client)
+}
+
+func resourceCollectorConfigurationRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	configuration, err := client.(*humio.Client).Collectors().GetConfiguration(
+		d.Id(),
+	)
+	if err != nil {
+		return diag.Errorf("could not get collector configuration: %s", err)
+	}
+	return resourceDataFromCollectorConfiguration(configuration, d)
+}
+
+func resourceDataFromCollectorConfiguration(a *humio.CollectorConfiguration, d *schema.ResourceData) diag.Diagnostics {
+	err := d.Set("name", a.Name)
+	if err != nil {
+		return diag.Errorf("error setting name for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("description", a.Description)
+	if err != nil {
+		return diag.Errorf("error setting description for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("yaml", a.YAML)
+	if err != nil {
+		return diag.Errorf("error setting yaml for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("version", a.Version)
+	if err != nil {
+		return diag.Errorf("error setting version for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceCollectorConfigurationUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	configuration, err := collectorConfigurationFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain collector configuration from resource data: %s", err)
+	}
+
+	_, err = client.(*humio.Client).Collectors().UpdateConfiguration(
+		&configuration,
+	)
+	if err != nil {
+		return diag.Errorf("could not update collector configuration: %s", err)
+	}
+	return resourceCollectorConfigurationRead(ctx, d, client)
+}
+
+func resourceCollectorConfigurationDelete(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	err := client.(*humio.Client).Collectors().DeleteConfiguration(
+		d.Id(),
+	)
+	if err != nil {
+		return diag.Errorf("could not delete collector configuration: %s", err)
+	}
+	return nil
+}
+
+func collectorConfigurationFromResourceData(d *schema.ResourceData) (humio.CollectorConfiguration, error) {
+	return humio.CollectorConfiguration{
+		ID:          d.Id(),
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		YAML:        d.Get("yaml").(string),
+		Version:     d.Get("version").(int),
+	}, nil
+}
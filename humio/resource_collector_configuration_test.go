@@ -0,0 +1,177 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCollectorConfigurationRequiredFields(t *testing.T) {
+	config := collectorConfigurationEmpty
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "name" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "yaml" is required, but no definition was found.`)},
+	}, nil)
+}
+
+func TestAccCollectorConfigurationInvalidSettings(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{Config: collectorConfigurationInvalidYAML, ExpectError: regexp.MustCompile(`expected yaml to be a YAML mapping`)},
+	}, nil)
+}
+
+func TestAccCollectorConfigurationBasicToFull(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: collectorConfigurationBasic,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_collector_configuration.test", "name", "collector-configuration-test"),
+				resource.TestCheckResourceAttr("humio_collector_configuration.test", "description", ""),
+				resource.TestCheckResourceAttrSet("humio_collector_configuration.test", "version"),
+			),
+		},
+		{
+			// Reformatting the YAML without changing its content must not cause a diff.
+			Config:   collectorConfigurationBasicReformatted,
+			PlanOnly: true,
+		},
+		{
+			Config: collectorConfigurationFull,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_collector_configuration.test", "description", "Ships syslog"),
+				resource.TestCheckResourceAttr("humio_collector_group.test", "name", "collector-group-test"),
+				resource.TestCheckResourceAttr("humio_collector_group.test", "filter", `system.os = "Linux"`),
+				resource.TestCheckResourceAttr("humio_collector_group.test", "configuration_ids.#", "1"),
+				resource.TestCheckTypeSetElemAttrPair("humio_collector_group.test", "configuration_ids.*", "humio_collector_configuration.test", "id"),
+			),
+		},
+		{
+			ResourceName:      "humio_collector_configuration.test",
+			ImportState:       true,
+			ImportStateVerify: true,
+		},
+		{
+			ResourceName:      "humio_collector_group.test",
+			ImportState:       true,
+			ImportStateVerify: true,
+		},
+	}, testAccCheckCollectorConfigurationDestroy)
+}
+
+func testAccCheckCollectorConfigurationDestroy(s *terraform.State) error {
+	conn := testAccProviders["humio"].Meta().(*humio.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		switch rs.Type {
+		case "humio_collector_configuration":
+			resp, err := conn.Collectors().GetConfiguration(rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("collector configuration still exists for id %s: %#+v", rs.Primary.ID, *resp)
+			}
+		case "humio_collector_group":
+			resp, err := conn.Collectors().GetGroup(rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("collector group still exists for id %s: %#+v", rs.Primary.ID, *resp)
+			}
+		}
+	}
+	return nil
+}
+
+const collectorConfigurationEmpty = `
+resource "humio_collector_configuration" "test" {}
+`
+
+const collectorConfigurationInvalidYAML = `
+resource "humio_collector_configuration" "test" {
+    name = "collector-configuration-test"
+    yaml = "- just\n- a list"
+}
+`
+
+const collectorConfigurationBasic = `
+resource "humio_collector_configuration" "test" {
+    name = "collector-configuration-test"
+    yaml = <<EOT
+sources:
+  syslog:
+    type: syslog
+    mode: udp
+    port: 514
+    sink: humio
+EOT
+}
+`
+
+const collectorConfigurationBasicReformatted = `
+resource "humio_collector_configuration" "test" {
+    name = "collector-configuration-test"
+    yaml = <<EOT
+# Reindented, with a comment
+sources:
+    syslog: {type: syslog, mode: "udp", port: 514, sink: humio}
+EOT
+}
+`
+
+const collectorConfigurationFull = `
+resource "humio_collector_configuration" "test" {
+    name        = "collector-configuration-test"
+    description = "Ships syslog"
+    yaml        = <<EOT
+sources:
+  syslog:
+    type: syslog
+    mode: tcp
+    port: 514
+    sink: humio
+EOT
+}
+
+resource "humio_collector_group" "test" {
+    name              = "collector-group-test"
+    filter            = "system.os = \"Linux\""
+    configuration_ids = [humio_collector_configuration.test.id]
+}
+`
+
+var wantCollectorConfiguration = humio.CollectorConfiguration{
+	ID:          "",
+	Name:        "test-collector-configuration",
+	Description: "Collects syslog",
+	YAML:        "sources:\n  syslog:\n    type: syslog\n",
+	Version:     3,
+}
+
+func TestEncodeDecodeCollectorConfigurationResource(t *testing.T) {
+	res := resourceCollectorConfiguration()
+	data := res.TestResourceData()
+	resourceDataFromCollectorConfiguration(&wantCollectorConfiguration, data)
+	got, err := collectorConfigurationFromResourceData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantCollectorConfiguration, got) {
+		t.Error(cmp.Diff(wantCollectorConfiguration, got))
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func resourceCollectorGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCollectorGroupCreate,
+		ReadContext:   resourceCollectorGroupRead,
+		UpdateContext: resourceCollectorGroupUpdate,
+		DeleteContext: resourceCollectorGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			},
+			// The filter is a query on the collectors' metadata, e.g. hostname or OS; an empty filter matches no collectors.
+			"filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"configuration_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceCollectorGroupCreate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	group, err := collectorGroupFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain collector group from resource data: %s", err)
+	}
+
+	g, err := client.(*humio.Client).Collectors().AddGroup(
+		&group,
+	)
+	if err != nil {
+		return diag.Errorf("could not create collector group: %s", err)
+	}
+	d.SetId(g.ID)
+
+	return resourceCollectorGroupRead(ctx, d, client)
+}
+
+func resourceCollectorGroupRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	group, err := client.(*humio.Client).Collectors().GetGroup(
+		d.Id(),
+	)
+	if err != nil {
+		return diag.Errorf("could not get collector group: %s", err)
+	}
+	return resourceDataFromCollectorGroup(group, d)
+}
+
+func resourceDataFromCollectorGroup(a *humio.CollectorGroup, d *schema.ResourceData) diag.Diagnostics {
+	err := d.Set("name", a.Name)
+	if err != nil {
+		return diag.Errorf("error setting name for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("filter", a.Filter)
+	if err != nil {
+		return diag.Errorf("error setting filter for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("configuration_ids", a.ConfigurationIDs)
+	if err != nil {
+		return diag.Errorf("error setting configuration_ids for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceCollectorGroupUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	group, err := collectorGroupFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain collector group from resource data: %s", err)
+	}
+
+	_, err = client.(*humio.Client).Collectors().UpdateGroup(
+		&group,
+	)
+	if err != nil {
+		return diag.Errorf("could not update collector group: %s", err)
+	}
+	return resourceCollectorGroupRead(ctx, d, client)
+}
+
+func resourceCollectorGroupDelete(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	err := client.(*humio.Client).Collectors().DeleteGroup(
+		d.Id(),
+	)
+	if err != nil {
+		return diag.Errorf("could not delete collector group: %s", err)
+	}
+	return nil
+}
+
+func collectorGroupFromResourceData(d *schema.ResourceData) (humio.CollectorGroup, error) {
+	configurationIDs := convertInterfaceListToStringSlice(d.Get("configuration_ids").(*schema.Set).List())
+	sort.Strings(configurationIDs)
+
+	return humio.CollectorGroup{
+		ID:               d.Id(),
+		Name:             d.Get("name").(string),
+		Filter:           d.Get("filter").(string),
+		ConfigurationIDs: configurationIDs,
+	}, nil
+}
@@ -0,0 +1,55 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"regexp"
+	"testing"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCollectorGroupRequiredFields(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{Config: collectorGroupEmpty, ExpectError: regexp.MustCompile(`The argument "name" is required, but no definition was found.`)},
+	}, nil)
+}
+
+const collectorGroupEmpty = `
+resource "humio_collector_group" "test" {}
+`
+
+var wantCollectorGroup = humio.CollectorGroup{
+	ID:               "",
+	Name:             "test-collector-group",
+	Filter:           `system.os = "Linux"`,
+	ConfigurationIDs: []string{"abcdef", "ghijkl"},
+}
+
+func TestEncodeDecodeCollectorGroupResource(t *testing.T) {
+	res := resourceCollectorGroup()
+	data := res.TestResourceData()
+	resourceDataFromCollectorGroup(&wantCollectorGroup, data)
+	got, err := collectorGroupFromResourceData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantCollectorGroup, got) {
+		t.Error(cmp.Diff(wantCollectorGroup, got))
+	}
+}
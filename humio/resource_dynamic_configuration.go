@@ -0,0 +1,169 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func resourceDynamicConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDynamicConfigurationCreate,
+		ReadContext:   resourceDynamicConfigurationRead,
+		UpdateContext: resourceDynamicConfigurationUpdate,
+		DeleteContext: resourceDynamicConfigurationDelete,
+		CustomizeDiff: resourceDynamicConfigurationCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			},
+			"value": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+// resourceDynamicConfigurationCustomizeDiff checks that the key exists, and that the value parses as the type the
+// server declares for the key, as the server itself only deals in strings.
+func resourceDynamicConfigurationCustomizeDiff(_ context.Context, d *schema.ResourceDiff, client interface{}) error {
+	if !d.NewValueKnown("key") || !d.NewValueKnown("value") {
+		return nil
+	}
+	if !d.HasChange("key") && !d.HasChange("value") {
+		return nil
+	}
+	key := d.Get("key").(string)
+	value := d.Get("value").(string)
+
+	configs, err := client.(*humio.Client).DynamicConfigs().List()
+	if err != nil {
+		return fmt.Errorf("could not list dynamic configuration to validate %s: %s", key, err)
+	}
+	for _, config := range configs {
+		if config.Key != key {
+			continue
+		}
+		if !dynamicConfigurationValueHasType(value, config.ValueType) {
+			return fmt.Errorf("dynamic configuration %s expects a value of type %s, got %q", key, config.ValueType, value)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown dynamic configuration key: %s", key)
+}
+
+// dynamicConfigurationValueHasType reports whether the string form of a value parses as the given value type. Types
+// the provider does not know are left to the server.
+func dynamicConfigurationValueHasType(value, valueType string) bool {
+	var err error
+	switch valueType {
+	case humio.DynamicConfigValueTypeBoolean:
+		_, err = strconv.ParseBool(value)
+	case humio.DynamicConfigValueTypeInteger:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case humio.DynamicConfigValueTypeFloat:
+		_, err = strconv.ParseFloat(value, 64)
+	}
+	return err == nil
+}
+
+func resourceDynamicConfigurationCreate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	dynamicConfig, err := dynamicConfigurationFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain dynamic configuration from resource data: %s", err)
+	}
+
+	err = client.(*humio.Client).DynamicConfigs().Set(
+		dynamicConfig.Key,
+		dynamicConfig.Value,
+	)
+	if err != nil {
+		return diag.Errorf("could not set dynamic configuration: %s", err)
+	}
+	d.SetId(dynamicConfig.Key)
+
+	return resourceDynamicConfigurationRead(ctx, d, client)
+}
+
+func resourceDynamicConfigurationRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	dynamicConfig, err := client.(*humio.Client).DynamicConfigs().Get(
+		d.Id(),
+	)
+	if err != nil {
+		return diag.Errorf("could not get dynamic configuration: %s", err)
+	}
+	return resourceDataFromDynamicConfiguration(dynamicConfig, d)
+}
+
+func resourceDataFromDynamicConfiguration(a *humio.DynamicConfig, d *schema.ResourceData) diag.Diagnostics {
+	err := d.Set("key", a.Key)
+	if err != nil {
+		return diag.Errorf("error setting key for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("value", a.Value)
+	if err != nil {
+		return diag.Errorf("error setting value for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceDynamicConfigurationUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	dynamicConfig, err := dynamicConfigurationFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain dynamic configuration from resource data: %s", err)
+	}
+
+	err = client.(*humio.Client).DynamicConfigs().Set(
+		dynamicConfig.Key,
+		dynamicConfig.Value,
+	)
+	if err != nil {
+		return diag.Errorf("could not update dynamic configuration: %s", err)
+	}
+	return resourceDynamicConfigurationRead(ctx, d, client)
+}
+
+func resourceDynamicConfigurationDelete(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	err := client.(*humio.Client).DynamicConfigs().Unset(
+		d.Id(),
+	)
+	if err != nil {
+		return diag.Errorf("could not reset dynamic configuration: %s", err)
+	}
+	return nil
+}
+
+func dynamicConfigurationFromResourceData(d *schema.ResourceData) (humio.DynamicConfig, error) {
+	return humio.DynamicConfig{
+		Key:   d.Get("key").(string),
+		Value: d.Get("value").(string),
+	}, nil
+}
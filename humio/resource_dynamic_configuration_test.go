@@ -0,0 +1,101 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"regexp"
+	"testing"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDynamicConfigurationRequiredFields(t *testing.T) {
+	config := dynamicConfigurationEmpty
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "key" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "value" is required, but no definition was found.`)},
+	}, nil)
+}
+
+func TestAccDynamicConfigurationInvalidSettings(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{Config: dynamicConfigurationUnknownKey, ExpectError: regexp.MustCompile(`unknown dynamic configuration key: NoSuchConfigKey`)},
+		{Config: dynamicConfigurationWrongType, ExpectError: regexp.MustCompile(`dynamic configuration MaxIngestRequestSize expects a value of type Integer, got "large"`)},
+	}, nil)
+}
+
+func TestAccDynamicConfigurationBasic(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: dynamicConfigurationBasic,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_dynamic_configuration.test", "id", "MaxIngestRequestSize"),
+				resource.TestCheckResourceAttr("humio_dynamic_configuration.test", "key", "MaxIngestRequestSize"),
+				resource.TestCheckResourceAttr("humio_dynamic_configuration.test", "value", "33554432"),
+			),
+		},
+		{
+			ResourceName:      "humio_dynamic_configuration.test",
+			ImportState:       true,
+			ImportStateVerify: true,
+		},
+	}, nil)
+}
+
+const dynamicConfigurationEmpty = `
+resource "humio_dynamic_configuration" "test" {}
+`
+
+const dynamicConfigurationUnknownKey = `
+resource "humio_dynamic_configuration" "test" {
+    key   = "NoSuchConfigKey"
+    value = "1"
+}
+`
+
+const dynamicConfigurationWrongType = `
+resource "humio_dynamic_configuration" "test" {
+    key   = "MaxIngestRequestSize"
+    value = "large"
+}
+`
+
+const dynamicConfigurationBasic = `
+resource "humio_dynamic_configuration" "test" {
+    key   = "MaxIngestRequestSize"
+    value = "33554432"
+}
+`
+
+var wantDynamicConfiguration = humio.DynamicConfig{
+	Key:   "QueryCoordinatorMaxHeapFraction",
+	Value: "0.5",
+}
+
+func TestEncodeDecodeDynamicConfigurationResource(t *testing.T) {
+	res := resourceDynamicConfiguration()
+	data := res.TestResourceData()
+	resourceDataFromDynamicConfiguration(&wantDynamicConfiguration, data)
+	got, err := dynamicConfigurationFromResourceData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantDynamicConfiguration, got) {
+		t.Error(cmp.Diff(wantDynamicConfiguration, got))
+	}
+}
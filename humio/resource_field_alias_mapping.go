@@ -0,0 +1,195 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func resourceFieldAliasMapping() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceFieldAliasMappingCreate,
+		ReadContext:   resourceFieldAliasMappingRead,
+		UpdateContext: resourceFieldAliasMappingUpdate,
+		DeleteContext: resourceFieldAliasMappingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"mapping_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"schema_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// The mapping applies to events in repositories whose tags match all of these, e.g. "#type" = "accesslog".
+			"tags": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// Maps schema fields to the source fields aliased to them. Being a map, the plan lists each alias that
+			// changes individually.
+			"aliases": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"original_fields_to_keep": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceFieldAliasMappingCreate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	mapping, err := fieldAliasMappingFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain field alias mapping from resource data: %s", err)
+	}
+
+	m, err := client.(*humio.Client).FieldAliases().AddMapping(
+		&mapping,
+	)
+	if err != nil {
+		return diag.Errorf("could not create field alias mapping: %s", err)
+	}
+	d.SetId(fmt.Sprintf("%s+%s", m.SchemaID, m.ID))
+
+	return resourceFieldAliasMappingRead(ctx, d, client)
+}
+
+func resourceFieldAliasMappingRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	parts := parseRepositoryAndID(d.Id())
+	//we check that we have parsed the id into the correct number of segments
+	if parts[0] == "" || parts[1] == "" {
+		return diag.Errorf("error importing humio_field_alias_mapping. Please make sure the ID is in the form SCHEMAID+MAPPINGID (i.e. 12345678901234567890123456789012+12345678901234567890123456789012")
+	}
+	// If we don't have a schema when importing, we parse it from the ID.
+	if _, ok := d.GetOk("schema_id"); !ok {
+		err := d.Set("schema_id", parts[0])
+		if err != nil {
+			return diag.Errorf("error setting schema_id for resource %s: %s", d.Id(), err)
+		}
+	}
+
+	mapping, err := client.(*humio.Client).FieldAliases().GetMapping(
+		d.Get("schema_id").(string),
+		parts[1],
+	)
+	if err != nil {
+		return diag.Errorf("could not get field alias mapping: %s", err)
+	}
+	return resourceDataFromFieldAliasMapping(mapping, d)
+}
+
+func resourceDataFromFieldAliasMapping(a *humio.FieldAliasMapping, d *schema.ResourceData) diag.Diagnostics {
+	err := d.Set("mapping_id", a.ID)
+	if err != nil {
+		return diag.Errorf("error setting mapping_id for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("schema_id", a.SchemaID)
+	if err != nil {
+		return diag.Errorf("error setting schema_id for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("name", a.Name)
+	if err != nil {
+		return diag.Errorf("error setting name for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("tags", a.Tags)
+	if err != nil {
+		return diag.Errorf("error setting tags for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("aliases", a.Aliases)
+	if err != nil {
+		return diag.Errorf("error setting aliases for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("original_fields_to_keep", a.OriginalFieldsToKeep)
+	if err != nil {
+		return diag.Errorf("error setting original_fields_to_keep for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceFieldAliasMappingUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	mapping, err := fieldAliasMappingFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain field alias mapping from resource data: %s", err)
+	}
+
+	_, err = client.(*humio.Client).FieldAliases().UpdateMapping(
+		&mapping,
+	)
+	if err != nil {
+		return diag.Errorf("could not update field alias mapping: %s", err)
+	}
+	return resourceFieldAliasMappingRead(ctx, d, client)
+}
+
+func resourceFieldAliasMappingDelete(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	err := client.(*humio.Client).FieldAliases().DeleteMapping(
+		d.Get("schema_id").(string),
+		d.Get("mapping_id").(string),
+	)
+	if err != nil {
+		return diag.Errorf("could not delete field alias mapping: %s", err)
+	}
+	return nil
+}
+
+func fieldAliasMappingFromResourceData(d *schema.ResourceData) (humio.FieldAliasMapping, error) {
+	tags := map[string]string{}
+	for name, value := range d.Get("tags").(map[string]interface{}) {
+		tags[name] = value.(string)
+	}
+	aliases := map[string]string{}
+	for alias, source := range d.Get("aliases").(map[string]interface{}) {
+		aliases[alias] = source.(string)
+	}
+	originalFieldsToKeep := convertInterfaceListToStringSlice(d.Get("original_fields_to_keep").(*schema.Set).List())
+	sort.Strings(originalFieldsToKeep)
+
+	return humio.FieldAliasMapping{
+		ID:                   d.Get("mapping_id").(string),
+		SchemaID:             d.Get("schema_id").(string),
+		Name:                 d.Get("name").(string),
+		Tags:                 tags,
+		Aliases:              aliases,
+		OriginalFieldsToKeep: originalFieldsToKeep,
+	}, nil
+}
@@ -0,0 +1,83 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"regexp"
+	"testing"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccFieldAliasMappingRequiredFields(t *testing.T) {
+	config := fieldAliasMappingEmpty
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "schema_id" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "name" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "tags" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "aliases" is required, but no definition was found.`)},
+	}, nil)
+}
+
+func TestAccFieldAliasMappingInvalidInputs(t *testing.T) {
+	config := fieldAliasMappingInvalidInputs
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`Inappropriate value for attribute "tags"`)},
+		{Config: config, ExpectError: regexp.MustCompile(`Inappropriate value for attribute "aliases"`)},
+	}, nil)
+}
+
+const fieldAliasMappingEmpty = `
+resource "humio_field_alias_mapping" "test" {}
+`
+
+const fieldAliasMappingInvalidInputs = `
+resource "humio_field_alias_mapping" "test" {
+    schema_id = "abcdef"
+    name      = "field-alias-mapping-test"
+    tags      = ["invalid"]
+    aliases   = "invalid"
+}
+`
+
+var wantFieldAliasMapping = humio.FieldAliasMapping{
+	ID:       "ghijkl",
+	SchemaID: "abcdef",
+	Name:     "test-field-alias-mapping",
+	Tags: map[string]string{
+		"#type": "accesslog",
+	},
+	Aliases: map[string]string{
+		"source.ip":      "client",
+		"event.duration": "response_time",
+	},
+	OriginalFieldsToKeep: []string{"client", "response_time"},
+}
+
+func TestEncodeDecodeFieldAliasMappingResource(t *testing.T) {
+	res := resourceFieldAliasMapping()
+	data := res.TestResourceData()
+	resourceDataFromFieldAliasMapping(&wantFieldAliasMapping, data)
+	got, err := fieldAliasMappingFromResourceData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantFieldAliasMapping, got) {
+		t.Error(cmp.Diff(wantFieldAliasMapping, got))
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func resourceFieldAliasSchema() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceFieldAliasSchemaCreate,
+		ReadContext:   resourceFieldAliasSchemaRead,
+		UpdateContext: resourceFieldAliasSchemaUpdate,
+		DeleteContext: resourceFieldAliasSchemaDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"field": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  humio.FieldAliasFieldTypeString,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+								humio.FieldAliasFieldTypeString,
+								humio.FieldAliasFieldTypeNumber,
+								humio.FieldAliasFieldTypeBoolean,
+							}, false)),
+						},
+					},
+				},
+			},
+			// Only one schema can be active on an organization at a time, so activating this schema deactivates any
+			// other.
+			"active": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceFieldAliasSchemaCreate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	fieldAliasSchema, err := fieldAliasSchemaFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain field alias schema from resource data: %s", err)
+	}
+
+	s, err := client.(*humio.Client).FieldAliases().AddSchema(
+		&fieldAliasSchema,
+	)
+	if err != nil {
+		return diag.Errorf("could not create field alias schema: %s", err)
+	}
+	d.SetId(s.ID)
+
+	if fieldAliasSchema.Active {
+		err = client.(*humio.Client).FieldAliases().EnableSchema(s.ID)
+		if err != nil {
+			return diag.Errorf("could not activate field alias schema: %s", err)
+		}
+	}
+
+	return resourceFieldAliasSchemaRead(ctx, d, client)
+}
+
+func resourceFieldAliasSchemaRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	fieldAliasSchema, err := client.(*humio.Client).FieldAliases().GetSchema(
+		d.Id(),
+	)
+	if err != nil {
+		return diag.Errorf("could not get field alias schema: %s", err)
+	}
+	return resourceDataFromFieldAliasSchema(fieldAliasSchema, d)
+}
+
+func resourceDataFromFieldAliasSchema(a *humio.FieldAliasSchema, d *schema.ResourceData) diag.Diagnostics {
+	err := d.Set("name", a.Name)
+	if err != nil {
+		return diag.Errorf("error setting name for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("field", fieldsFromFieldAliasSchema(a))
+	if err != nil {
+		return diag.Errorf("error setting field for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("active", a.Active)
+	if err != nil {
+		return diag.Errorf("error setting active for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func fieldsFromFieldAliasSchema(a *humio.FieldAliasSchema) []tfMap {
+	fields := make([]tfMap, len(a.Fields))
+	for i, field := range a.Fields {
+		fields[i] = tfMap{
+			"name": field.Name,
+			"type": field.Type,
+		}
+	}
+	return fields
+}
+
+func resourceFieldAliasSchemaUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	fieldAliasSchema, err := fieldAliasSchemaFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain field alias schema from resource data: %s", err)
+	}
+
+	if d.HasChanges("name", "field") {
+		_, err = client.(*humio.Client).FieldAliases().UpdateSchema(
+			&fieldAliasSchema,
+		)
+		if err != nil {
+			return diag.Errorf("could not update field alias schema: %s", err)
+		}
+	}
+
+	if d.HasChange("active") {
+		if fieldAliasSchema.Active {
+			err = client.(*humio.Client).FieldAliases().EnableSchema(d.Id())
+			if err != nil {
+				return diag.Errorf("could not activate field alias schema: %s", err)
+			}
+		} else {
+			err = client.(*humio.Client).FieldAliases().DisableSchema()
+			if err != nil {
+				return diag.Errorf("could not deactivate field alias schema: %s", err)
+			}
+		}
+	}
+
+	return resourceFieldAliasSchemaRead(ctx, d, client)
+}
+
+func resourceFieldAliasSchemaDelete(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	if d.Get("active").(bool) {
+		err := client.(*humio.Client).FieldAliases().DisableSchema()
+		if err != nil {
+			return diag.Errorf("could not deactivate field alias schema: %s", err)
+		}
+	}
+
+	err := client.(*humio.Client).FieldAliases().DeleteSchema(
+		d.Id(),
+	)
+	if err != nil {
+		return diag.Errorf("could not delete field alias schema: %s", err)
+	}
+	return nil
+}
+
+func fieldAliasSchemaFromResourceData(d *schema.ResourceData) (humio.FieldAliasSchema, error) {
+	var fields []humio.FieldAliasSchemaField
+	for _, rawField := range d.Get("field").(*schema.Set).List() {
+		field := rawField.(tfMap)
+		fields = append(fields, humio.FieldAliasSchemaField{
+			Name: field["name"].(string),
+			Type: field["type"].(string),
+		})
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Name < fields[j].Name
+	})
+
+	return humio.FieldAliasSchema{
+		ID:     d.Id(),
+		Name:   d.Get("name").(string),
+		Fields: fields,
+		Active: d.Get("active").(bool),
+	}, nil
+}
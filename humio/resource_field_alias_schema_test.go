@@ -0,0 +1,169 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccFieldAliasSchemaRequiredFields(t *testing.T) {
+	config := fieldAliasSchemaEmpty
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "name" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`At least 1 "field" blocks are required.`)},
+	}, nil)
+}
+
+func TestAccFieldAliasSchemaInvalidSettings(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{Config: fieldAliasSchemaInvalidSettings, ExpectError: regexp.MustCompile(`expected type to be one of \["string" "number" "boolean"\], got ip`)},
+	}, nil)
+}
+
+func TestAccFieldAliasSchemaBasicToFull(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: fieldAliasSchemaBasic,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_field_alias_schema.test", "name", "field-alias-schema-test"),
+				resource.TestCheckResourceAttr("humio_field_alias_schema.test", "field.#", "1"),
+				resource.TestCheckTypeSetElemNestedAttrs("humio_field_alias_schema.test", "field.*", map[string]string{
+					"name": "source.ip",
+					"type": "string",
+				}),
+				resource.TestCheckResourceAttr("humio_field_alias_schema.test", "active", "false"),
+			),
+		},
+		{
+			Config: fieldAliasSchemaFull,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_field_alias_schema.test", "field.#", "2"),
+				resource.TestCheckTypeSetElemNestedAttrs("humio_field_alias_schema.test", "field.*", map[string]string{
+					"name": "http.response.status_code",
+					"type": "number",
+				}),
+				resource.TestCheckResourceAttr("humio_field_alias_schema.test", "active", "true"),
+				resource.TestCheckResourceAttr("humio_field_alias_mapping.test", "name", "field-alias-mapping-test"),
+				resource.TestCheckResourceAttr("humio_field_alias_mapping.test", "tags.%", "1"),
+				resource.TestCheckResourceAttr("humio_field_alias_mapping.test", "tags.#type", "accesslog"),
+				resource.TestCheckResourceAttr("humio_field_alias_mapping.test", "aliases.%", "2"),
+				resource.TestCheckResourceAttr("humio_field_alias_mapping.test", "aliases.source.ip", "client"),
+				resource.TestCheckResourceAttr("humio_field_alias_mapping.test", "aliases.http.response.status_code", "status"),
+			),
+		},
+		{
+			Config: fieldAliasSchemaBasic,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_field_alias_schema.test", "field.#", "1"),
+				resource.TestCheckResourceAttr("humio_field_alias_schema.test", "active", "false"),
+			),
+		},
+	}, testAccCheckFieldAliasSchemaDestroy)
+}
+
+func testAccCheckFieldAliasSchemaDestroy(s *terraform.State) error {
+	conn := testAccProviders["humio"].Meta().(*humio.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "humio_field_alias_schema" {
+			continue
+		}
+		resp, err := conn.FieldAliases().GetSchema(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("field alias schema still exists for id %s: %#+v", rs.Primary.ID, *resp)
+		}
+	}
+	return nil
+}
+
+const fieldAliasSchemaEmpty = `
+resource "humio_field_alias_schema" "test" {}
+`
+
+const fieldAliasSchemaInvalidSettings = `
+resource "humio_field_alias_schema" "test" {
+    name = "field-alias-schema-test"
+    field {
+        name = "source.ip"
+        type = "ip"
+    }
+}
+`
+
+const fieldAliasSchemaBasic = `
+resource "humio_field_alias_schema" "test" {
+    name = "field-alias-schema-test"
+    field {
+        name = "source.ip"
+    }
+}
+`
+
+const fieldAliasSchemaFull = `
+resource "humio_field_alias_schema" "test" {
+    name   = "field-alias-schema-test"
+    active = true
+    field {
+        name = "source.ip"
+    }
+    field {
+        name = "http.response.status_code"
+        type = "number"
+    }
+}
+
+resource "humio_field_alias_mapping" "test" {
+    schema_id = humio_field_alias_schema.test.id
+    name      = "field-alias-mapping-test"
+    tags      = {
+        "#type" = "accesslog"
+    }
+    aliases   = {
+        "source.ip"                 = "client"
+        "http.response.status_code" = "status"
+    }
+}
+`
+
+var wantFieldAliasSchema = humio.FieldAliasSchema{
+	ID:   "",
+	Name: "test-field-alias-schema",
+	Fields: []humio.FieldAliasSchemaField{
+		{Name: "event.duration", Type: humio.FieldAliasFieldTypeNumber},
+		{Name: "source.ip", Type: humio.FieldAliasFieldTypeString},
+	},
+	Active: true,
+}
+
+func TestEncodeDecodeFieldAliasSchemaResource(t *testing.T) {
+	res := resourceFieldAliasSchema()
+	data := res.TestResourceData()
+	resourceDataFromFieldAliasSchema(&wantFieldAliasSchema, data)
+	got, err := fieldAliasSchemaFromResourceData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantFieldAliasSchema, got) {
+		t.Error(cmp.Diff(wantFieldAliasSchema, got))
+	}
+}
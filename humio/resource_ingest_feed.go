@@ -0,0 +1,260 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+var rxIAMRoleARN = regexp.MustCompile(`^arn:aws[a-zA-Z-]*:iam::\d{12}:role/.+$`)
+
+func resourceIngestFeed() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIngestFeedCreate,
+		ReadContext:   resourceIngestFeedRead,
+		UpdateContext: resourceIngestFeedUpdate,
+		DeleteContext: resourceIngestFeedDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"ingest_feed_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"parser": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"role_arn": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(rxIAMRoleARN, "must be a valid IAM role ARN")),
+			},
+			"sqs_url": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateURL,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"compression": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  humio.IngestFeedCompressionAuto,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					humio.IngestFeedCompressionAuto,
+					humio.IngestFeedCompressionGzip,
+					humio.IngestFeedCompressionNone,
+				}, false)),
+			},
+			"preprocessing": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  humio.IngestFeedPreprocessingSplitNewline,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					humio.IngestFeedPreprocessingSplitNewline,
+					humio.IngestFeedPreprocessingSplitAwsRecords,
+				}, false)),
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func resourceIngestFeedCreate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	ingestFeed, err := ingestFeedFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain ingest feed from resource data: %s", err)
+	}
+
+	if diags := checkParserExists(client.(*humio.Client), d.Get("repository").(string), ingestFeed.Parser); diags != nil {
+		return diags
+	}
+
+	f, err := client.(*humio.Client).IngestFeeds().Add(
+		d.Get("repository").(string),
+		&ingestFeed,
+	)
+	if err != nil {
+		return diag.Errorf("could not create ingest feed: %s", err)
+	}
+	d.SetId(fmt.Sprintf("%s+%s", d.Get("repository"), f.ID))
+
+	return resourceIngestFeedRead(ctx, d, client)
+}
+
+func resourceIngestFeedRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	parts := parseRepositoryAndID(d.Id())
+	//we check that we have parsed the id into the correct number of segments
+	if parts[0] == "" || parts[1] == "" {
+		return diag.Errorf("error importing humio_ingest_feed. Please make sure the ID is in the form REPOSITORYNAME+INGESTFEEDID (i.e. myRepoName+12345678901234567890123456789012")
+	}
+	// If we don't have a repository when importing, we parse it from the ID.
+	if _, ok := d.GetOk("repository"); !ok {
+		err := d.Set("repository", parts[0])
+		if err != nil {
+			return diag.Errorf("error setting repository for resource %s: %s", d.Id(), err)
+		}
+	}
+
+	ingestFeed, err := client.(*humio.Client).IngestFeeds().Get(
+		d.Get("repository").(string),
+		parts[1],
+	)
+	if err != nil {
+		return diag.Errorf("could not get ingest feed: %s", err)
+	}
+	return resourceDataFromIngestFeed(ingestFeed, d)
+}
+
+func resourceDataFromIngestFeed(a *humio.IngestFeed, d *schema.ResourceData) diag.Diagnostics {
+	err := d.Set("ingest_feed_id", a.ID)
+	if err != nil {
+		return diag.Errorf("error setting ingest_feed_id for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("name", a.Name)
+	if err != nil {
+		return diag.Errorf("error setting name for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("description", a.Description)
+	if err != nil {
+		return diag.Errorf("error setting description for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("parser", a.Parser)
+	if err != nil {
+		return diag.Errorf("error setting parser for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("role_arn", a.RoleArn)
+	if err != nil {
+		return diag.Errorf("error setting role_arn for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("sqs_url", a.SqsURL)
+	if err != nil {
+		return diag.Errorf("error setting sqs_url for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("region", a.Region)
+	if err != nil {
+		return diag.Errorf("error setting region for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("compression", a.Compression)
+	if err != nil {
+		return diag.Errorf("error setting compression for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("preprocessing", a.Preprocessing)
+	if err != nil {
+		return diag.Errorf("error setting preprocessing for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("enabled", a.Enabled)
+	if err != nil {
+		return diag.Errorf("error setting enabled for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceIngestFeedUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	ingestFeed, err := ingestFeedFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain ingest feed from resource data: %s", err)
+	}
+
+	if d.HasChange("parser") {
+		if diags := checkParserExists(client.(*humio.Client), d.Get("repository").(string), ingestFeed.Parser); diags != nil {
+			return diags
+		}
+	}
+
+	_, err = client.(*humio.Client).IngestFeeds().Update(
+		d.Get("repository").(string),
+		&ingestFeed,
+	)
+	if err != nil {
+		return diag.Errorf("could not update ingest feed: %s", err)
+	}
+	return resourceIngestFeedRead(ctx, d, client)
+}
+
+func resourceIngestFeedDelete(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	ingestFeed, err := ingestFeedFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain ingest feed from resource data: %s", err)
+	}
+
+	err = client.(*humio.Client).IngestFeeds().Delete(
+		d.Get("repository").(string),
+		ingestFeed.ID,
+	)
+	if err != nil {
+		return diag.Errorf("could not delete ingest feed: %s", err)
+	}
+	return nil
+}
+
+func ingestFeedFromResourceData(d *schema.ResourceData) (humio.IngestFeed, error) {
+	return humio.IngestFeed{
+		ID:            d.Get("ingest_feed_id").(string),
+		Name:          d.Get("name").(string),
+		Description:   d.Get("description").(string),
+		Parser:        d.Get("parser").(string),
+		RoleArn:       d.Get("role_arn").(string),
+		SqsURL:        d.Get("sqs_url").(string),
+		Region:        d.Get("region").(string),
+		Compression:   d.Get("compression").(string),
+		Preprocessing: d.Get("preprocessing").(string),
+		Enabled:       d.Get("enabled").(bool),
+	}, nil
+}
+
+// checkParserExists returns an error diagnostic if the given parser cannot be found in the repository. Built-in parsers
+// are looked up by name as well, so e.g. "json" and "kv" are accepted.
+func checkParserExists(client *humio.Client, repository, parser string) diag.Diagnostics {
+	if _, err := client.Parsers().Get(repository, parser); err != nil {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Parser not found",
+			Detail:   fmt.Sprintf("could not find parser %q in repository %q: %s", parser, repository, err),
+		}}
+	}
+	return nil
+}
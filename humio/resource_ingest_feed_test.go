@@ -0,0 +1,129 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"regexp"
+	"testing"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIngestFeedRequiredFields(t *testing.T) {
+	config := ingestFeedEmpty
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "repository" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "name" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "parser" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "role_arn" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "sqs_url" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "region" is required, but no definition was found.`)},
+	}, nil)
+}
+
+func TestAccIngestFeedInvalidInputs(t *testing.T) {
+	config := ingestFeedInvalidInputs
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`Inappropriate value for attribute "repository"`)},
+		{Config: config, ExpectError: regexp.MustCompile(`Inappropriate value for attribute "name"`)},
+		{Config: config, ExpectError: regexp.MustCompile(`Inappropriate value for attribute "parser"`)},
+		{Config: config, ExpectError: regexp.MustCompile(`Inappropriate value for attribute "enabled"`)},
+	}, nil)
+}
+
+func TestAccIngestFeedInvalidSettings(t *testing.T) {
+	config := ingestFeedInvalidSettings
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`must be a valid IAM role ARN`)},
+		{Config: config, ExpectError: regexp.MustCompile(`not-a-url must be an absolute URL`)},
+		{Config: config, ExpectError: regexp.MustCompile(`expected compression to be one of \["Auto" "Gzip" "None"\], got Zip`)},
+		{Config: config, ExpectError: regexp.MustCompile(`expected preprocessing to be one of \["SplitNewline" "SplitAwsRecords"\], got Split`)},
+	}, nil)
+}
+
+func TestAccIngestFeedUnknownParser(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{Config: ingestFeedUnknownParser, ExpectError: regexp.MustCompile(`could not find parser "does-not-exist" in repository "sandbox"`)},
+	}, nil)
+}
+
+const ingestFeedEmpty = `
+resource "humio_ingest_feed" "test" {}
+`
+
+const ingestFeedInvalidInputs = `
+resource "humio_ingest_feed" "test" {
+    repository = ["invalid"]
+    name       = ["invalid"]
+    parser     = ["invalid"]
+    role_arn   = "arn:aws:iam::123456789012:role/humio-ingest"
+    sqs_url    = "https://sqs.eu-west-1.amazonaws.com/123456789012/humio-ingest"
+    region     = "eu-west-1"
+    enabled    = ["invalid"]
+}
+`
+
+const ingestFeedInvalidSettings = `
+resource "humio_ingest_feed" "test" {
+    repository    = "sandbox"
+    name          = "ingest-feed-test"
+    parser        = "json"
+    role_arn      = "humio-ingest"
+    sqs_url       = "not-a-url"
+    region        = "eu-west-1"
+    compression   = "Zip"
+    preprocessing = "Split"
+}
+`
+
+const ingestFeedUnknownParser = `
+resource "humio_ingest_feed" "test" {
+    repository = "sandbox"
+    name       = "ingest-feed-test"
+    parser     = "does-not-exist"
+    role_arn   = "arn:aws:iam::123456789012:role/humio-ingest"
+    sqs_url    = "https://sqs.eu-west-1.amazonaws.com/123456789012/humio-ingest"
+    region     = "eu-west-1"
+}
+`
+
+var wantIngestFeed = humio.IngestFeed{
+	ID:            "",
+	Name:          "test-ingest-feed",
+	Description:   "cloudtrail logs",
+	Parser:        "json",
+	RoleArn:       "arn:aws:iam::123456789012:role/humio-ingest",
+	SqsURL:        "https://sqs.eu-west-1.amazonaws.com/123456789012/humio-ingest",
+	Region:        "eu-west-1",
+	Compression:   humio.IngestFeedCompressionGzip,
+	Preprocessing: humio.IngestFeedPreprocessingSplitAwsRecords,
+	Enabled:       true,
+}
+
+func TestEncodeDecodeIngestFeedResource(t *testing.T) {
+	res := resourceIngestFeed()
+	data := res.TestResourceData()
+	resourceDataFromIngestFeed(&wantIngestFeed, data)
+	got, err := ingestFeedFromResourceData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantIngestFeed, got) {
+		t.Error(cmp.Diff(wantIngestFeed, got))
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func resourceIngestListener() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIngestListenerCreate,
+		ReadContext:   resourceIngestListenerRead,
+		UpdateContext: resourceIngestListenerUpdate,
+		DeleteContext: resourceIngestListenerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"ingest_listener_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"protocol": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					humio.IngestListenerProtocolTCP,
+					humio.IngestListenerProtocolUDP,
+					humio.IngestListenerProtocolGelfTCP,
+					humio.IngestListenerProtocolGelfUDP,
+				}, false)),
+			},
+			"port": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsPortNumber),
+			},
+			"bind_interface": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "0.0.0.0",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPAddress),
+			},
+			"parser": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"charset": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "UTF-8",
+			},
+			"vhost": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func resourceIngestListenerCreate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	ingestListener, err := ingestListenerFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain ingest listener from resource data: %s", err)
+	}
+
+	if diags := checkParserExists(client.(*humio.Client), d.Get("repository").(string), ingestListener.Parser); diags != nil {
+		return diags
+	}
+
+	l, err := client.(*humio.Client).IngestListeners().Add(
+		d.Get("repository").(string),
+		&ingestListener,
+	)
+	if err != nil {
+		return diag.Errorf("could not create ingest listener: %s", err)
+	}
+	d.SetId(fmt.Sprintf("%s+%s", d.Get("repository"), l.ID))
+
+	return resourceIngestListenerRead(ctx, d, client)
+}
+
+func resourceIngestListenerRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	parts := parseRepositoryAndID(d.Id())
+	//we check that we have parsed the id into the correct number of segments
+	if parts[0] == "" || parts[1] == "" {
+		return diag.Errorf("error importing humio_ingest_listener. Please make sure the ID is in the form REPOSITORYNAME+INGESTLISTENERID (i.e. myRepoName+12345678901234567890123456789012")
+	}
+	// If we don't have a repository when importing, we parse it from the ID.
+	if _, ok := d.GetOk("repository"); !ok {
+		err := d.Set("repository", parts[0])
+		if err != nil {
+			return diag.Errorf("error setting repository for resource %s: %s", d.Id(), err)
+		}
+	}
+
+	ingestListener, err := client.(*humio.Client).IngestListeners().Get(
+		d.Get("repository").(string),
+		parts[1],
+	)
+	if err != nil {
+		return diag.Errorf("could not get ingest listener: %s", err)
+	}
+	return resourceDataFromIngestListener(ingestListener, d)
+}
+
+func resourceDataFromIngestListener(a *humio.IngestListener, d *schema.ResourceData) diag.Diagnostics {
+	err := d.Set("ingest_listener_id", a.ID)
+	if err != nil {
+		return diag.Errorf("error setting ingest_listener_id for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("name", a.Name)
+	if err != nil {
+		return diag.Errorf("error setting name for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("protocol", a.Protocol)
+	if err != nil {
+		return diag.Errorf("error setting protocol for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("port", a.Port)
+	if err != nil {
+		return diag.Errorf("error setting port for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("bind_interface", a.BindInterface)
+	if err != nil {
+		return diag.Errorf("error setting bind_interface for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("parser", a.Parser)
+	if err != nil {
+		return diag.Errorf("error setting parser for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("charset", a.Charset)
+	if err != nil {
+		return diag.Errorf("error setting charset for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("vhost", a.VHost)
+	if err != nil {
+		return diag.Errorf("error setting vhost for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("enabled", a.Enabled)
+	if err != nil {
+		return diag.Errorf("error setting enabled for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceIngestListenerUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	ingestListener, err := ingestListenerFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain ingest listener from resource data: %s", err)
+	}
+
+	if d.HasChange("parser") {
+		if diags := checkParserExists(client.(*humio.Client), d.Get("repository").(string), ingestListener.Parser); diags != nil {
+			return diags
+		}
+	}
+
+	_, err = client.(*humio.Client).IngestListeners().Update(
+		d.Get("repository").(string),
+		&ingestListener,
+	)
+	if err != nil {
+		return diag.Errorf("could not update ingest listener: %s", err)
+	}
+	return resourceIngestListenerRead(ctx, d, client)
+}
+
+func resourceIngestListenerDelete(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	ingestListener, err := ingestListenerFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain ingest listener from resource data: %s", err)
+	}
+
+	err = client.(*humio.Client).IngestListeners().Delete(
+		ingestListener.ID,
+	)
+	if err != nil {
+		return diag.Errorf("could not delete ingest listener: %s", err)
+	}
+	return nil
+}
+
+func ingestListenerFromResourceData(d *schema.ResourceData) (humio.IngestListener, error) {
+	return humio.IngestListener{
+		ID:            d.Get("ingest_listener_id").(string),
+		Name:          d.Get("name").(string),
+		Protocol:      d.Get("protocol").(string),
+		Port:          d.Get("port").(int),
+		BindInterface: d.Get("bind_interface").(string),
+		Parser:        d.Get("parser").(string),
+		Charset:       d.Get("charset").(string),
+		VHost:         d.Get("vhost").(int),
+		Enabled:       d.Get("enabled").(bool),
+	}, nil
+}
@@ -0,0 +1,217 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIngestListenerRequiredFields(t *testing.T) {
+	config := ingestListenerEmpty
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "repository" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "name" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "protocol" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "port" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "parser" is required, but no definition was found.`)},
+	}, nil)
+}
+
+func TestAccIngestListenerInvalidInputs(t *testing.T) {
+	config := ingestListenerInvalidInputs
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`Inappropriate value for attribute "repository"`)},
+		{Config: config, ExpectError: regexp.MustCompile(`Inappropriate value for attribute "name"`)},
+		{Config: config, ExpectError: regexp.MustCompile(`Inappropriate value for attribute "port"`)},
+		{Config: config, ExpectError: regexp.MustCompile(`Inappropriate value for attribute "enabled"`)},
+	}, nil)
+}
+
+func TestAccIngestListenerInvalidSettings(t *testing.T) {
+	config := ingestListenerInvalidSettings
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`expected protocol to be one of \["TCP" "UDP" "GELF_TCP" "GELF_UDP"\], got SCTP`)},
+		{Config: config, ExpectError: regexp.MustCompile(`expected "port" to be a valid port number, got: 70000`)},
+		{Config: config, ExpectError: regexp.MustCompile(`expected bind_interface to contain a valid IP, got: localhost`)},
+	}, nil)
+}
+
+func TestAccIngestListenerBasic(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: ingestListenerBasic,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "repository", "sandbox"),
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "name", "ingest-listener-test"),
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "protocol", "UDP"),
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "port", "5514"),
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "bind_interface", "0.0.0.0"),
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "parser", "kv"),
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "charset", "UTF-8"),
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "vhost", "0"),
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "enabled", "true"),
+				resource.TestCheckResourceAttrSet("humio_ingest_listener.test", "ingest_listener_id"),
+			),
+		},
+	}, testAccCheckIngestListenerDestroy)
+}
+
+func TestAccIngestListenerBasicToFull(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: ingestListenerBasic,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "protocol", "UDP"),
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "port", "5514"),
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "parser", "kv"),
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "enabled", "true"),
+			),
+		},
+		{
+			Config:             ingestListenerFull,
+			PlanOnly:           true,
+			ExpectNonEmptyPlan: true,
+		},
+		{
+			Config: ingestListenerFull,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "repository", "sandbox"),
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "name", "ingest-listener-test"),
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "protocol", "TCP"),
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "port", "6514"),
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "bind_interface", "127.0.0.1"),
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "parser", "json"),
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "charset", "ISO-8859-1"),
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "vhost", "1"),
+				resource.TestCheckResourceAttr("humio_ingest_listener.test", "enabled", "false"),
+			),
+		},
+	}, testAccCheckIngestListenerDestroy)
+}
+
+func TestAccIngestListenerUnknownParser(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{Config: ingestListenerUnknownParser, ExpectError: regexp.MustCompile(`could not find parser "does-not-exist" in repository "sandbox"`)},
+	}, nil)
+}
+
+func testAccCheckIngestListenerDestroy(s *terraform.State) error {
+	conn := testAccProviders["humio"].Meta().(*humio.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "humio_ingest_listener" {
+			continue
+		}
+		parts := parseRepositoryAndID(rs.Primary.ID)
+		resp, err := conn.IngestListeners().Get(parts[0], parts[1])
+		if err == nil {
+			return fmt.Errorf("ingest listener still exists for id %s: %#+v", rs.Primary.ID, *resp)
+		}
+	}
+	return nil
+}
+
+const ingestListenerEmpty = `
+resource "humio_ingest_listener" "test" {}
+`
+
+const ingestListenerInvalidInputs = `
+resource "humio_ingest_listener" "test" {
+    repository = ["invalid"]
+    name       = ["invalid"]
+    protocol   = "TCP"
+    port       = ["invalid"]
+    parser     = "kv"
+    enabled    = ["invalid"]
+}
+`
+
+const ingestListenerInvalidSettings = `
+resource "humio_ingest_listener" "test" {
+    repository     = "sandbox"
+    name           = "ingest-listener-test"
+    protocol       = "SCTP"
+    port           = 70000
+    bind_interface = "localhost"
+    parser         = "kv"
+}
+`
+
+const ingestListenerBasic = `
+resource "humio_ingest_listener" "test" {
+    repository = "sandbox"
+    name       = "ingest-listener-test"
+    protocol   = "UDP"
+    port       = 5514
+    parser     = "kv"
+}
+`
+
+const ingestListenerFull = `
+resource "humio_ingest_listener" "test" {
+    repository     = "sandbox"
+    name           = "ingest-listener-test"
+    protocol       = "TCP"
+    port           = 6514
+    bind_interface = "127.0.0.1"
+    parser         = "json"
+    charset        = "ISO-8859-1"
+    vhost          = 1
+    enabled        = false
+}
+`
+
+const ingestListenerUnknownParser = `
+resource "humio_ingest_listener" "test" {
+    repository = "sandbox"
+    name       = "ingest-listener-test"
+    protocol   = "UDP"
+    port       = 5514
+    parser     = "does-not-exist"
+}
+`
+
+var wantIngestListener = humio.IngestListener{
+	ID:            "",
+	Name:          "test-ingest-listener",
+	Protocol:      humio.IngestListenerProtocolGelfUDP,
+	Port:          12201,
+	BindInterface: "0.0.0.0",
+	Parser:        "json",
+	Charset:       "UTF-8",
+	VHost:         2,
+	Enabled:       true,
+}
+
+func TestEncodeDecodeIngestListenerResource(t *testing.T) {
+	res := resourceIngestListener()
+	data := res.TestResourceData()
+	resourceDataFromIngestListener(&wantIngestListener, data)
+	got, err := ingestListenerFromResourceData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantIngestListener, got) {
+		t.Error(cmp.Diff(wantIngestListener, got))
+	}
+}
@@ -0,0 +1,439 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+// interactionTypeBlocks maps each interaction type to the block holding its settings.
+var interactionTypeBlocks = map[string]string{
+	humio.InteractionTypeCustomLink:    "url",
+	humio.InteractionTypeSearchLink:    "search_link",
+	humio.InteractionTypeDashboardLink: "dashboard_link",
+}
+
+func resourceInteraction() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceInteractionCreate,
+		ReadContext:   resourceInteractionRead,
+		UpdateContext: resourceInteractionUpdate,
+		DeleteContext: resourceInteractionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"interaction_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"view": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					humio.InteractionTypeCustomLink,
+					humio.InteractionTypeSearchLink,
+					humio.InteractionTypeDashboardLink,
+				}, false)),
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"title_template": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"condition": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"field": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"operator": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+								humio.InteractionConditionOperatorEqual,
+								humio.InteractionConditionOperatorNotEqual,
+								humio.InteractionConditionOperatorContains,
+								humio.InteractionConditionOperatorNotContains,
+								humio.InteractionConditionOperatorStartsWith,
+								humio.InteractionConditionOperatorEndsWith,
+								humio.InteractionConditionOperatorPresent,
+								humio.InteractionConditionOperatorNotPresent,
+							}, false)),
+						},
+						"argument": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"url": {
+				Type:          schema.TypeSet,
+				MaxItems:      1,
+				ConflictsWith: []string{"search_link", "dashboard_link"},
+				Optional:      true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url_template": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"open_in_new_tab": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"url_encode_arguments": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+					},
+				},
+			},
+			"search_link": {
+				Type:          schema.TypeSet,
+				MaxItems:      1,
+				ConflictsWith: []string{"url", "dashboard_link"},
+				Optional:      true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"query_string": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"repository": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"arguments": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"open_in_new_tab": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"use_widget_time_window": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			"dashboard_link": {
+				Type:          schema.TypeSet,
+				MaxItems:      1,
+				ConflictsWith: []string{"url", "search_link"},
+				Optional:      true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dashboard_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"repository": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						// Maps dashboard parameters to the values, typically field references, to set them to.
+						"parameters": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"open_in_new_tab": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"use_widget_time_window": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceInteractionCreate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	interaction, err := interactionFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain interaction from resource data: %s", err)
+	}
+
+	i, err := client.(*humio.Client).Interactions().Add(
+		d.Get("view").(string),
+		&interaction,
+	)
+	if err != nil {
+		return diag.Errorf("could not create interaction: %s", err)
+	}
+	d.SetId(fmt.Sprintf("%s+%s", d.Get("view"), i.ID))
+
+	return resourceInteractionRead(ctx, d, client)
+}
+
+func resourceInteractionRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	parts := parseRepositoryAndID(d.Id())
+	//we check that we have parsed the id into the correct number of segments
+	if parts[0] == "" || parts[1] == "" {
+		return diag.Errorf("error importing humio_interaction. Please make sure the ID is in the form VIEWNAME+INTERACTIONID (i.e. myViewName+12345678901234567890123456789012")
+	}
+	// If we don't have a view when importing, we parse it from the ID.
+	if _, ok := d.GetOk("view"); !ok {
+		err := d.Set("view", parts[0])
+		if err != nil {
+			return diag.Errorf("error setting view for resource %s: %s", d.Id(), err)
+		}
+	}
+
+	interaction, err := client.(*humio.Client).Interactions().Get(
+		d.Get("view").(string),
+		parts[1],
+	)
+	if err != nil {
+		return diag.Errorf("could not get interaction: %s", err)
+	}
+	return resourceDataFromInteraction(interaction, d)
+}
+
+func resourceDataFromInteraction(a *humio.Interaction, d *schema.ResourceData) diag.Diagnostics {
+	err := d.Set("interaction_id", a.ID)
+	if err != nil {
+		return diag.Errorf("error setting interaction_id for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("type", a.Type)
+	if err != nil {
+		return diag.Errorf("error setting type for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("name", a.Name)
+	if err != nil {
+		return diag.Errorf("error setting name for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("title_template", a.TitleTemplate)
+	if err != nil {
+		return diag.Errorf("error setting title_template for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("description", a.Description)
+	if err != nil {
+		return diag.Errorf("error setting description for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("condition", conditionsFromInteraction(a))
+	if err != nil {
+		return diag.Errorf("error setting condition for resource %s: %s", d.Id(), err)
+	}
+
+	switch a.Type {
+	case humio.InteractionTypeCustomLink:
+		if err := d.Set("url", urlFromInteraction(a)); err != nil {
+			return diag.Errorf("error setting url settings for resource %s: %s", d.Id(), err)
+		}
+	case humio.InteractionTypeSearchLink:
+		if err := d.Set("search_link", searchLinkFromInteraction(a)); err != nil {
+			return diag.Errorf("error setting search_link settings for resource %s: %s", d.Id(), err)
+		}
+	case humio.InteractionTypeDashboardLink:
+		if err := d.Set("dashboard_link", dashboardLinkFromInteraction(a)); err != nil {
+			return diag.Errorf("error setting dashboard_link settings for resource %s: %s", d.Id(), err)
+		}
+	default:
+		return diag.Errorf("unsupported interaction type: %s", a.Type)
+	}
+
+	return nil
+}
+
+func resourceInteractionUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	interaction, err := interactionFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain interaction from resource data: %s", err)
+	}
+
+	_, err = client.(*humio.Client).Interactions().Update(
+		d.Get("view").(string),
+		&interaction,
+	)
+	if err != nil {
+		return diag.Errorf("could not update interaction: %s", err)
+	}
+	return resourceInteractionRead(ctx, d, client)
+}
+
+func resourceInteractionDelete(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	err := client.(*humio.Client).Interactions().Delete(
+		d.Get("view").(string),
+		d.Get("interaction_id").(string),
+	)
+	if err != nil {
+		return diag.Errorf("could not delete interaction: %s", err)
+	}
+	return nil
+}
+
+func interactionFromResourceData(d *schema.ResourceData) (humio.Interaction, error) {
+	interaction := humio.Interaction{
+		Type:          d.Get("type").(string),
+		ID:            d.Get("interaction_id").(string),
+		Name:          d.Get("name").(string),
+		TitleTemplate: d.Get("title_template").(string),
+		Description:   d.Get("description").(string),
+	}
+
+	for _, rawCondition := range d.Get("condition").([]interface{}) {
+		condition := rawCondition.(tfMap)
+		interaction.Conditions = append(interaction.Conditions, humio.InteractionCondition{
+			FieldName: condition["field"].(string),
+			Operator:  condition["operator"].(string),
+			Argument:  condition["argument"].(string),
+		})
+	}
+
+	block, ok := interactionTypeBlocks[interaction.Type]
+	if !ok {
+		return humio.Interaction{}, fmt.Errorf("unsupported interaction type: %s", interaction.Type)
+	}
+	rawProperties := d.Get(block).(*schema.Set).List()
+	if len(rawProperties) == 0 {
+		return humio.Interaction{}, fmt.Errorf("a %s block is required for interactions of type %s", block, interaction.Type)
+	}
+	properties := rawProperties[0].(tfMap)
+
+	switch interaction.Type {
+	case humio.InteractionTypeCustomLink:
+		interaction.CustomLinkInteraction = humio.CustomLinkInteraction{
+			URLTemplate:   properties["url_template"].(string),
+			OpenInNewTab:  properties["open_in_new_tab"].(bool),
+			URLEncodeArgs: properties["url_encode_arguments"].(bool),
+		}
+	case humio.InteractionTypeSearchLink:
+		interaction.SearchLinkInteraction = humio.SearchLinkInteraction{
+			QueryString:         properties["query_string"].(string),
+			RepoOrViewName:      properties["repository"].(string),
+			Arguments:           interactionArgumentsFromMap(properties["arguments"].(map[string]interface{})),
+			OpenInNewTab:        properties["open_in_new_tab"].(bool),
+			UseWidgetTimeWindow: properties["use_widget_time_window"].(bool),
+		}
+	case humio.InteractionTypeDashboardLink:
+		interaction.DashboardLinkInteraction = humio.DashboardLinkInteraction{
+			DashboardName:       properties["dashboard_name"].(string),
+			RepoOrViewName:      properties["repository"].(string),
+			Arguments:           interactionArgumentsFromMap(properties["parameters"].(map[string]interface{})),
+			OpenInNewTab:        properties["open_in_new_tab"].(bool),
+			UseWidgetTimeWindow: properties["use_widget_time_window"].(bool),
+		}
+	}
+
+	return interaction, nil
+}
+
+// interactionArgumentsFromMap returns the arguments sorted by name, so the order sent to the server is stable.
+func interactionArgumentsFromMap(m map[string]interface{}) []humio.InteractionArgument {
+	arguments := []humio.InteractionArgument{}
+	for name, value := range m {
+		arguments = append(arguments, humio.InteractionArgument{
+			Name:  name,
+			Value: value.(string),
+		})
+	}
+	sort.Slice(arguments, func(i, j int) bool {
+		return arguments[i].Name < arguments[j].Name
+	})
+	return arguments
+}
+
+func interactionArgumentsToMap(arguments []humio.InteractionArgument) map[string]string {
+	m := make(map[string]string)
+	for _, argument := range arguments {
+		m[argument.Name] = argument.Value
+	}
+	return m
+}
+
+func conditionsFromInteraction(a *humio.Interaction) []tfMap {
+	conditions := make([]tfMap, len(a.Conditions))
+	for i, condition := range a.Conditions {
+		conditions[i] = tfMap{
+			"field":    condition.FieldName,
+			"operator": condition.Operator,
+			"argument": condition.Argument,
+		}
+	}
+	return conditions
+}
+
+func urlFromInteraction(a *humio.Interaction) []tfMap {
+	s := tfMap{}
+	s["url_template"] = a.CustomLinkInteraction.URLTemplate
+	s["open_in_new_tab"] = a.CustomLinkInteraction.OpenInNewTab
+	s["url_encode_arguments"] = a.CustomLinkInteraction.URLEncodeArgs
+	return []tfMap{s}
+}
+
+func searchLinkFromInteraction(a *humio.Interaction) []tfMap {
+	s := tfMap{}
+	s["query_string"] = a.SearchLinkInteraction.QueryString
+	s["repository"] = a.SearchLinkInteraction.RepoOrViewName
+	s["arguments"] = interactionArgumentsToMap(a.SearchLinkInteraction.Arguments)
+	s["open_in_new_tab"] = a.SearchLinkInteraction.OpenInNewTab
+	s["use_widget_time_window"] = a.SearchLinkInteraction.UseWidgetTimeWindow
+	return []tfMap{s}
+}
+
+func dashboardLinkFromInteraction(a *humio.Interaction) []tfMap {
+	s := tfMap{}
+	s["dashboard_name"] = a.DashboardLinkInteraction.DashboardName
+	s["repository"] = a.DashboardLinkInteraction.RepoOrViewName
+	s["parameters"] = interactionArgumentsToMap(a.DashboardLinkInteraction.Arguments)
+	s["open_in_new_tab"] = a.DashboardLinkInteraction.OpenInNewTab
+	s["use_widget_time_window"] = a.DashboardLinkInteraction.UseWidgetTimeWindow
+	return []tfMap{s}
+}
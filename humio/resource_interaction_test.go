@@ -0,0 +1,289 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccInteractionRequiredFields(t *testing.T) {
+	config := interactionEmpty
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "view" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "type" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "name" is required, but no definition was found.`)},
+	}, nil)
+}
+
+func TestAccInteractionInvalidInputs(t *testing.T) {
+	config := interactionInvalidInputs
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`Inappropriate value for attribute "view"`)},
+		{Config: config, ExpectError: regexp.MustCompile(`Inappropriate value for attribute "name"`)},
+		{Config: config, ExpectError: regexp.MustCompile(`An argument named "url" is not expected here`)},
+		{Config: config, ExpectError: regexp.MustCompile(`An argument named "search_link" is not expected here`)},
+		{Config: config, ExpectError: regexp.MustCompile(`An argument named "dashboard_link" is not expected here`)},
+	}, nil)
+}
+
+func TestAccInteractionInvalidSettings(t *testing.T) {
+	config := interactionInvalidSettings
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`expected type to be one of \["CustomLinkInteraction" "SearchLinkInteraction" "DashboardLinkInteraction"\], got LinkInteraction`)},
+		{Config: config, ExpectError: regexp.MustCompile(`expected operator to be one of .*, got Matches`)},
+	}, nil)
+}
+
+func TestAccInteractionConflictingBlocks(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{Config: interactionConflictingBlocks, ExpectError: regexp.MustCompile(`"search_link": conflicts with url`)},
+	}, nil)
+}
+
+func TestAccInteractionMissingTypeBlock(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{Config: interactionMissingTypeBlock, ExpectError: regexp.MustCompile(`a search_link block is required for interactions of type SearchLinkInteraction`)},
+	}, nil)
+}
+
+func TestAccInteractionURL(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: interactionURL,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_interaction.test", "view", "sandbox"),
+				resource.TestCheckResourceAttr("humio_interaction.test", "type", humio.InteractionTypeCustomLink),
+				resource.TestCheckResourceAttr("humio_interaction.test", "name", "interaction-test-url"),
+				resource.TestCheckResourceAttr("humio_interaction.test", "condition.#", "1"),
+				resource.TestCheckResourceAttr("humio_interaction.test", "condition.0.field", "ip"),
+				resource.TestCheckResourceAttr("humio_interaction.test", "condition.0.operator", "Present"),
+				resource.TestCheckResourceAttr("humio_interaction.test", "url.#", "1"),
+				resource.TestCheckTypeSetElemNestedAttrs("humio_interaction.test", "url.*", map[string]string{
+					"url_template":         "https://ipinfo.io/{{ fields.ip }}",
+					"open_in_new_tab":      "true",
+					"url_encode_arguments": "true",
+				}),
+				resource.TestCheckResourceAttrSet("humio_interaction.test", "interaction_id"),
+			),
+		},
+	}, testAccCheckInteractionDestroy)
+}
+
+func TestAccInteractionSearchLink(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: interactionSearchLink,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_interaction.test", "type", humio.InteractionTypeSearchLink),
+				resource.TestCheckResourceAttr("humio_interaction.test", "title_template", "Requests from {{ fields.ip }}"),
+				resource.TestCheckResourceAttr("humio_interaction.test", "search_link.#", "1"),
+				resource.TestCheckTypeSetElemNestedAttrs("humio_interaction.test", "search_link.*", map[string]string{
+					"query_string":           "ip = ?ip",
+					"repository":             "sandbox",
+					"arguments.%":            "1",
+					"arguments.ip":           "{{ fields.ip }}",
+					"use_widget_time_window": "true",
+				}),
+			),
+		},
+	}, testAccCheckInteractionDestroy)
+}
+
+func TestAccInteractionDashboardLink(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: interactionDashboardLink,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_interaction.test", "type", humio.InteractionTypeDashboardLink),
+				resource.TestCheckResourceAttr("humio_interaction.test", "dashboard_link.#", "1"),
+				resource.TestCheckTypeSetElemNestedAttrs("humio_interaction.test", "dashboard_link.*", map[string]string{
+					"dashboard_name":  "Host overview",
+					"repository":      "sandbox",
+					"parameters.%":    "2",
+					"parameters.host": "{{ fields.host }}",
+					"parameters.env":  "production",
+				}),
+			),
+		},
+	}, testAccCheckInteractionDestroy)
+}
+
+func testAccCheckInteractionDestroy(s *terraform.State) error {
+	conn := testAccProviders["humio"].Meta().(*humio.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "humio_interaction" {
+			continue
+		}
+		parts := parseRepositoryAndID(rs.Primary.ID)
+		resp, err := conn.Interactions().Get(parts[0], parts[1])
+		if err == nil {
+			return fmt.Errorf("interaction still exists for id %s: %#+v", rs.Primary.ID, *resp)
+		}
+	}
+	return nil
+}
+
+const interactionEmpty = `
+resource "humio_interaction" "test" {}
+`
+
+const interactionInvalidInputs = `
+resource "humio_interaction" "test" {
+    view           = ["invalid"]
+    type           = "CustomLinkInteraction"
+    name           = ["invalid"]
+    url            = "invalid"
+    search_link    = "invalid"
+    dashboard_link = "invalid"
+}
+`
+
+const interactionInvalidSettings = `
+resource "humio_interaction" "test" {
+    view = "sandbox"
+    type = "LinkInteraction"
+    name = "interaction-test"
+    condition {
+        field    = "ip"
+        operator = "Matches"
+    }
+    url {
+        url_template = "https://ipinfo.io/{{ fields.ip }}"
+    }
+}
+`
+
+const interactionConflictingBlocks = `
+resource "humio_interaction" "test" {
+    view = "sandbox"
+    type = "CustomLinkInteraction"
+    name = "interaction-test"
+    url {
+        url_template = "https://ipinfo.io/{{ fields.ip }}"
+    }
+    search_link {
+        query_string = "ip = ?ip"
+        repository   = "sandbox"
+    }
+}
+`
+
+const interactionMissingTypeBlock = `
+resource "humio_interaction" "test" {
+    view = "sandbox"
+    type = "SearchLinkInteraction"
+    name = "interaction-test"
+    url {
+        url_template = "https://ipinfo.io/{{ fields.ip }}"
+    }
+}
+`
+
+const interactionURL = `
+resource "humio_interaction" "test" {
+    view = "sandbox"
+    type = "CustomLinkInteraction"
+    name = "interaction-test-url"
+    condition {
+        field    = "ip"
+        operator = "Present"
+    }
+    url {
+        url_template = "https://ipinfo.io/{{ fields.ip }}"
+    }
+}
+`
+
+const interactionSearchLink = `
+resource "humio_interaction" "test" {
+    view           = "sandbox"
+    type           = "SearchLinkInteraction"
+    name           = "interaction-test-search-link"
+    title_template = "Requests from {{ fields.ip }}"
+    search_link {
+        query_string           = "ip = ?ip"
+        repository             = "sandbox"
+        arguments              = {
+            ip = "{{ fields.ip }}"
+        }
+        use_widget_time_window = true
+    }
+}
+`
+
+const interactionDashboardLink = `
+resource "humio_interaction" "test" {
+    view = "sandbox"
+    type = "DashboardLinkInteraction"
+    name = "interaction-test-dashboard-link"
+    condition {
+        field    = "host"
+        operator = "StartsWith"
+        argument = "web-"
+    }
+    dashboard_link {
+        dashboard_name = "Host overview"
+        repository     = "sandbox"
+        parameters     = {
+            host = "{{ fields.host }}"
+            env  = "production"
+        }
+    }
+}
+`
+
+var wantInteraction = humio.Interaction{
+	Type:          humio.InteractionTypeDashboardLink,
+	ID:            "",
+	Name:          "test-interaction",
+	TitleTemplate: "Open {{ fields.host }}",
+	Description:   "important",
+	Conditions: []humio.InteractionCondition{
+		{FieldName: "host", Operator: humio.InteractionConditionOperatorStartsWith, Argument: "web-"},
+		{FieldName: "env", Operator: humio.InteractionConditionOperatorPresent, Argument: ""},
+	},
+	DashboardLinkInteraction: humio.DashboardLinkInteraction{
+		DashboardName:  "Host overview",
+		RepoOrViewName: "sandbox",
+		Arguments: []humio.InteractionArgument{
+			{Name: "env", Value: "{{ fields.env }}"},
+			{Name: "host", Value: "{{ fields.host }}"},
+		},
+		OpenInNewTab:        true,
+		UseWidgetTimeWindow: true,
+	},
+}
+
+func TestEncodeDecodeInteractionResource(t *testing.T) {
+	res := resourceInteraction()
+	data := res.TestResourceData()
+	resourceDataFromInteraction(&wantInteraction, data)
+	got, err := interactionFromResourceData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantInteraction, got) {
+		t.Error(cmp.Diff(wantInteraction, got))
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func resourceIPFilter() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIPFilterCreate,
+		ReadContext:   resourceIPFilterRead,
+		UpdateContext: resourceIPFilterUpdate,
+		DeleteContext: resourceIPFilterDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// A list rather than a set, as rules are evaluated in order and the first match applies.
+			"rule": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+								humio.IPFilterActionAllow,
+								humio.IPFilterActionDeny,
+							}, false)),
+						},
+						"address": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validateIPFilterAddress),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateIPFilterAddress accepts a CIDR block or "all", which are the addresses the server understands in a rule.
+func validateIPFilterAddress(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if v == humio.IPFilterAddressAll {
+		return nil, nil
+	}
+	if _, _, err := net.ParseCIDR(v); err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a CIDR block or %q, got: %s", k, humio.IPFilterAddressAll, v)}
+	}
+	return nil, nil
+}
+
+func resourceIPFilterCreate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	ipFilter, err := ipFilterFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain IP filter from resource data: %s", err)
+	}
+
+	f, err := client.(*humio.Client).IPFilters().Add(
+		&ipFilter,
+	)
+	if err != nil {
+		return diag.Errorf("could not create IP filter: %s", err)
+	}
+	d.SetId(f.ID)
+
+	return resourceIPFilterRead(ctx, d, client)
+}
+
+func resourceIPFilterRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	ipFilter, err := client.(*humio.Client).IPFilters().Get(
+		d.Id(),
+	)
+	if err != nil {
+		return diag.Errorf("could not get IP filter: %s", err)
+	}
+	return resourceDataFromIPFilter(ipFilter, d)
+}
+
+func resourceDataFromIPFilter(a *humio.IPFilter, d *schema.ResourceData) diag.Diagnostics {
+	err := d.Set("name", a.Name)
+	if err != nil {
+		return diag.Errorf("error setting name for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("rule", ipFilterRulesFromIPFilter(a))
+	if err != nil {
+		return diag.Errorf("error setting rule for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func ipFilterRulesFromIPFilter(a *humio.IPFilter) []tfMap {
+	rules := make([]tfMap, len(a.Rules))
+	for i, rule := range a.Rules {
+		rules[i] = tfMap{
+			"action":  rule.Action,
+			"address": rule.Address,
+		}
+	}
+	return rules
+}
+
+func resourceIPFilterUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	ipFilter, err := ipFilterFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain IP filter from resource data: %s", err)
+	}
+
+	_, err = client.(*humio.Client).IPFilters().Update(
+		&ipFilter,
+	)
+	if err != nil {
+		return diag.Errorf("could not update IP filter: %s", err)
+	}
+	return resourceIPFilterRead(ctx, d, client)
+}
+
+func resourceIPFilterDelete(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	err := client.(*humio.Client).IPFilters().Delete(
+		d.Id(),
+	)
+	if err != nil {
+		return diag.Errorf("could not delete IP filter: %s", err)
+	}
+	return nil
+}
+
+func ipFilterFromResourceData(d *schema.ResourceData) (humio.IPFilter, error) {
+	rawRules := d.Get("rule").([]interface{})
+	rules := make([]humio.IPFilterRule, len(rawRules))
+	for i, rawRule := range rawRules {
+		rule := rawRule.(tfMap)
+		rules[i] = humio.IPFilterRule{
+			Action:  rule["action"].(string),
+			Address: rule["address"].(string),
+		}
+	}
+
+	return humio.IPFilter{
+		ID:    d.Id(),
+		Name:  d.Get("name").(string),
+		Rules: rules,
+	}, nil
+}
@@ -0,0 +1,149 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIPFilterRequiredFields(t *testing.T) {
+	config := ipFilterEmpty
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "name" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`At least 1 "rule" blocks are required.`)},
+	}, nil)
+}
+
+func TestAccIPFilterInvalidSettings(t *testing.T) {
+	config := ipFilterInvalidSettings
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`expected action to be one of \["allow" "deny"\], got permit`)},
+		{Config: config, ExpectError: regexp.MustCompile(`expected address to be a CIDR block or "all", got: 10.0.0.300/8`)},
+	}, nil)
+}
+
+func TestAccIPFilterBasicToFull(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: ipFilterBasic,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_ip_filter.test", "name", "ip-filter-test"),
+				resource.TestCheckResourceAttr("humio_ip_filter.test", "rule.#", "1"),
+				resource.TestCheckResourceAttr("humio_ip_filter.test", "rule.0.action", "allow"),
+				resource.TestCheckResourceAttr("humio_ip_filter.test", "rule.0.address", "all"),
+			),
+		},
+		{
+			Config: ipFilterFull,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_ip_filter.test", "name", "ip-filter-test-office"),
+				resource.TestCheckResourceAttr("humio_ip_filter.test", "rule.#", "3"),
+				resource.TestCheckResourceAttr("humio_ip_filter.test", "rule.0.action", "allow"),
+				resource.TestCheckResourceAttr("humio_ip_filter.test", "rule.0.address", "10.0.0.0/8"),
+				resource.TestCheckResourceAttr("humio_ip_filter.test", "rule.1.action", "allow"),
+				resource.TestCheckResourceAttr("humio_ip_filter.test", "rule.1.address", "2001:db8::/32"),
+				resource.TestCheckResourceAttr("humio_ip_filter.test", "rule.2.action", "deny"),
+				resource.TestCheckResourceAttr("humio_ip_filter.test", "rule.2.address", "all"),
+			),
+		},
+	}, testAccCheckIPFilterDestroy)
+}
+
+func testAccCheckIPFilterDestroy(s *terraform.State) error {
+	conn := testAccProviders["humio"].Meta().(*humio.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "humio_ip_filter" {
+			continue
+		}
+		resp, err := conn.IPFilters().Get(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("IP filter still exists for id %s: %#+v", rs.Primary.ID, *resp)
+		}
+	}
+	return nil
+}
+
+const ipFilterEmpty = `
+resource "humio_ip_filter" "test" {}
+`
+
+const ipFilterInvalidSettings = `
+resource "humio_ip_filter" "test" {
+    name = "ip-filter-test"
+    rule {
+        action  = "permit"
+        address = "10.0.0.300/8"
+    }
+}
+`
+
+const ipFilterBasic = `
+resource "humio_ip_filter" "test" {
+    name = "ip-filter-test"
+    rule {
+        action  = "allow"
+        address = "all"
+    }
+}
+`
+
+const ipFilterFull = `
+resource "humio_ip_filter" "test" {
+    name = "ip-filter-test-office"
+    rule {
+        action  = "allow"
+        address = "10.0.0.0/8"
+    }
+    rule {
+        action  = "allow"
+        address = "2001:db8::/32"
+    }
+    rule {
+        action  = "deny"
+        address = "all"
+    }
+}
+`
+
+var wantIPFilter = humio.IPFilter{
+	ID:   "",
+	Name: "test-ip-filter",
+	Rules: []humio.IPFilterRule{
+		{Action: humio.IPFilterActionAllow, Address: "192.168.0.0/16"},
+		{Action: humio.IPFilterActionDeny, Address: humio.IPFilterAddressAll},
+	},
+}
+
+func TestEncodeDecodeIPFilterResource(t *testing.T) {
+	res := resourceIPFilter()
+	data := res.TestResourceData()
+	resourceDataFromIPFilter(&wantIPFilter, data)
+	got, err := ipFilterFromResourceData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantIPFilter, got) {
+		t.Error(cmp.Diff(wantIPFilter, got))
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func resourceOIDCIdentityProvider() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOIDCIdentityProviderCreate,
+		ReadContext:   resourceOIDCIdentityProviderRead,
+		UpdateContext: resourceOIDCIdentityProviderUpdate,
+		DeleteContext: resourceOIDCIdentityProviderDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			},
+			// Users logging in with an email address in one of the domains are sent to this identity provider.
+			"domains": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"client_secret": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"issuer": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPS),
+			},
+			"token_endpoint_auth_method": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  humio.OIDCTokenEndpointAuthMethodClientSecretBasic,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					humio.OIDCTokenEndpointAuthMethodClientSecretBasic,
+					humio.OIDCTokenEndpointAuthMethodClientSecretPost,
+				}, false)),
+			},
+			"scopes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"user_claim": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "email",
+			},
+			"groups_claim": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			// The endpoints are discovered from the issuer unless set explicitly.
+			"authorization_endpoint": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"token_endpoint": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"userinfo_endpoint": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"jwks_endpoint": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"registration_endpoint": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"lazy_create_users": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"default_idp": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"debug": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceOIDCIdentityProviderCreate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	provider, err := oidcIdentityProviderFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain OIDC identity provider from resource data: %s", err)
+	}
+
+	p, err := client.(*humio.Client).IdentityProviders().AddOIDC(
+		&provider,
+	)
+	if err != nil {
+		return diag.Errorf("could not create OIDC identity provider: %s", err)
+	}
+	d.SetId(p.ID)
+
+	return resourceOIDCIdentityProviderRead(ctx, d, client)
+}
+
+func resourceOIDCIdentityProviderRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	provider, err := client.(*humio.Client).IdentityProviders().GetOIDC(
+		d.Id(),
+	)
+	if err != nil {
+		return diag.Errorf("could not get OIDC identity provider: %s", err)
+	}
+	return resourceDataFromOIDCIdentityProvider(provider, d)
+}
+
+func resourceDataFromOIDCIdentityProvider(a *humio.OIDCIdentityProvider, d *schema.ResourceData) diag.Diagnostics {
+	err := d.Set("name", a.Name)
+	if err != nil {
+		return diag.Errorf("error setting name for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("domains", a.Domains)
+	if err != nil {
+		return diag.Errorf("error setting domains for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("client_id", a.ClientID)
+	if err != nil {
+		return diag.Errorf("error setting client_id for resource %s: %s", d.Id(), err)
+	}
+	// The client secret is only returned to users allowed to see it, so we keep the one we know of otherwise.
+	if a.ClientSecret != "" {
+		err = d.Set("client_secret", a.ClientSecret)
+		if err != nil {
+			return diag.Errorf("error setting client_secret for resource %s: %s", d.Id(), err)
+		}
+	}
+	err = d.Set("issuer", a.Issuer)
+	if err != nil {
+		return diag.Errorf("error setting issuer for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("token_endpoint_auth_method", a.TokenEndpointAuthMethod)
+	if err != nil {
+		return diag.Errorf("error setting token_endpoint_auth_method for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("scopes", a.Scopes)
+	if err != nil {
+		return diag.Errorf("error setting scopes for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("user_claim", a.UserClaim)
+	if err != nil {
+		return diag.Errorf("error setting user_claim for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("groups_claim", a.GroupsClaim)
+	if err != nil {
+		return diag.Errorf("error setting groups_claim for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("authorization_endpoint", a.AuthorizationEndpoint)
+	if err != nil {
+		return diag.Errorf("error setting authorization_endpoint for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("token_endpoint", a.TokenEndpoint)
+	if err != nil {
+		return diag.Errorf("error setting token_endpoint for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("userinfo_endpoint", a.UserInfoEndpoint)
+	if err != nil {
+		return diag.Errorf("error setting userinfo_endpoint for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("jwks_endpoint", a.JWKSEndpoint)
+	if err != nil {
+		return diag.Errorf("error setting jwks_endpoint for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("registration_endpoint", a.RegistrationEndpoint)
+	if err != nil {
+		return diag.Errorf("error setting registration_endpoint for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("lazy_create_users", a.LazyCreateUsers)
+	if err != nil {
+		return diag.Errorf("error setting lazy_create_users for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("default_idp", a.DefaultIdP)
+	if err != nil {
+		return diag.Errorf("error setting default_idp for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("debug", a.Debug)
+	if err != nil {
+		return diag.Errorf("error setting debug for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceOIDCIdentityProviderUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	provider, err := oidcIdentityProviderFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain OIDC identity provider from resource data: %s", err)
+	}
+
+	_, err = client.(*humio.Client).IdentityProviders().UpdateOIDC(
+		&provider,
+	)
+	if err != nil {
+		return diag.Errorf("could not update OIDC identity provider: %s", err)
+	}
+	return resourceOIDCIdentityProviderRead(ctx, d, client)
+}
+
+func resourceOIDCIdentityProviderDelete(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	err := client.(*humio.Client).IdentityProviders().Delete(
+		d.Id(),
+	)
+	if err != nil {
+		return diag.Errorf("could not delete OIDC identity provider: %s", err)
+	}
+	return nil
+}
+
+func oidcIdentityProviderFromResourceData(d *schema.ResourceData) (humio.OIDCIdentityProvider, error) {
+	domains := convertInterfaceListToStringSlice(d.Get("domains").(*schema.Set).List())
+	sort.Strings(domains)
+
+	return humio.OIDCIdentityProvider{
+		ID:                      d.Id(),
+		Name:                    d.Get("name").(string),
+		Domains:                 domains,
+		ClientID:                d.Get("client_id").(string),
+		ClientSecret:            d.Get("client_secret").(string),
+		Issuer:                  d.Get("issuer").(string),
+		TokenEndpointAuthMethod: d.Get("token_endpoint_auth_method").(string),
+		Scopes:                  convertInterfaceListToStringSlice(d.Get("scopes").([]interface{})),
+		UserClaim:               d.Get("user_claim").(string),
+		GroupsClaim:             d.Get("groups_claim").(string),
+		AuthorizationEndpoint:   d.Get("authorization_endpoint").(string),
+		TokenEndpoint:           d.Get("token_endpoint").(string),
+		UserInfoEndpoint:        d.Get("userinfo_endpoint").(string),
+		JWKSEndpoint:            d.Get("jwks_endpoint").(string),
+		RegistrationEndpoint:    d.Get("registration_endpoint").(string),
+		LazyCreateUsers:         d.Get("lazy_create_users").(bool),
+		DefaultIdP:              d.Get("default_idp").(bool),
+		Debug:                   d.Get("debug").(bool),
+	}, nil
+}
@@ -0,0 +1,147 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"regexp"
+	"testing"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccOIDCIdentityProviderRequiredFields(t *testing.T) {
+	config := oidcIdentityProviderEmpty
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "name" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "domains" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "client_id" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "client_secret" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "issuer" is required, but no definition was found.`)},
+	}, nil)
+}
+
+func TestAccOIDCIdentityProviderInvalidSettings(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{Config: oidcIdentityProviderInvalidSettings, ExpectError: regexp.MustCompile(`expected token_endpoint_auth_method to be one of \["client_secret_basic" "client_secret_post"\], got private_key_jwt`)},
+	}, nil)
+}
+
+func TestAccOIDCIdentityProviderBasicToFull(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: oidcIdentityProviderBasic,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_oidc_identity_provider.test", "name", "oidc-identity-provider-test"),
+				resource.TestCheckResourceAttr("humio_oidc_identity_provider.test", "client_id", "humio"),
+				resource.TestCheckResourceAttr("humio_oidc_identity_provider.test", "token_endpoint_auth_method", "client_secret_basic"),
+				resource.TestCheckResourceAttr("humio_oidc_identity_provider.test", "user_claim", "email"),
+			),
+		},
+		{
+			Config: oidcIdentityProviderFull,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_oidc_identity_provider.test", "token_endpoint_auth_method", "client_secret_post"),
+				resource.TestCheckResourceAttr("humio_oidc_identity_provider.test", "scopes.#", "3"),
+				resource.TestCheckResourceAttr("humio_oidc_identity_provider.test", "groups_claim", "groups"),
+				resource.TestCheckResourceAttr("humio_oidc_identity_provider.test", "jwks_endpoint", "https://idp.example.com/keys"),
+			),
+		},
+		{
+			ResourceName:            "humio_oidc_identity_provider.test",
+			ImportState:             true,
+			ImportStateVerify:       true,
+			ImportStateVerifyIgnore: []string{"client_secret"},
+		},
+	}, testAccCheckIdentityProviderDestroy)
+}
+
+const oidcIdentityProviderEmpty = `
+resource "humio_oidc_identity_provider" "test" {}
+`
+
+const oidcIdentityProviderInvalidSettings = `
+resource "humio_oidc_identity_provider" "test" {
+    name                       = "oidc-identity-provider-test"
+    domains                    = ["example.com"]
+    client_id                  = "humio"
+    client_secret              = "secret"
+    issuer                     = "https://idp.example.com"
+    token_endpoint_auth_method = "private_key_jwt"
+}
+`
+
+const oidcIdentityProviderBasic = `
+resource "humio_oidc_identity_provider" "test" {
+    name          = "oidc-identity-provider-test"
+    domains       = ["example.com"]
+    client_id     = "humio"
+    client_secret = "secret"
+    issuer        = "https://idp.example.com"
+}
+`
+
+const oidcIdentityProviderFull = `
+resource "humio_oidc_identity_provider" "test" {
+    name                       = "oidc-identity-provider-test"
+    domains                    = ["example.com"]
+    client_id                  = "humio"
+    client_secret              = "rotated-secret"
+    issuer                     = "https://idp.example.com"
+    token_endpoint_auth_method = "client_secret_post"
+    scopes                     = ["openid", "email", "groups"]
+    groups_claim               = "groups"
+    authorization_endpoint     = "https://idp.example.com/authorize"
+    token_endpoint             = "https://idp.example.com/token"
+    userinfo_endpoint          = "https://idp.example.com/userinfo"
+    jwks_endpoint              = "https://idp.example.com/keys"
+}
+`
+
+var wantOIDCIdentityProvider = humio.OIDCIdentityProvider{
+	ID:                      "",
+	Name:                    "test-oidc-identity-provider",
+	Domains:                 []string{"example.com"},
+	ClientID:                "humio",
+	ClientSecret:            "secret",
+	Issuer:                  "https://idp.example.com",
+	TokenEndpointAuthMethod: humio.OIDCTokenEndpointAuthMethodClientSecretPost,
+	Scopes:                  []string{"openid", "email"},
+	UserClaim:               "email",
+	GroupsClaim:             "groups",
+	AuthorizationEndpoint:   "https://idp.example.com/authorize",
+	TokenEndpoint:           "https://idp.example.com/token",
+	UserInfoEndpoint:        "https://idp.example.com/userinfo",
+	JWKSEndpoint:            "https://idp.example.com/keys",
+	RegistrationEndpoint:    "",
+	LazyCreateUsers:         true,
+	DefaultIdP:              true,
+	Debug:                   false,
+}
+
+func TestEncodeDecodeOIDCIdentityProviderResource(t *testing.T) {
+	res := resourceOIDCIdentityProvider()
+	data := res.TestResourceData()
+	resourceDataFromOIDCIdentityProvider(&wantOIDCIdentityProvider, data)
+	got, err := oidcIdentityProviderFromResourceData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantOIDCIdentityProvider, got) {
+		t.Error(cmp.Diff(wantOIDCIdentityProvider, got))
+	}
+}
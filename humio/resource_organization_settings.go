@@ -0,0 +1,236 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+// organizationSettingsID is the ID of the organization settings, of which there is only one per organization.
+const organizationSettingsID = "organization"
+
+// organizationTokenPolicies lists the token security policies of the organization settings. Each policy gets the
+// attributes <prefix>_enabled and <prefix>_max_ttl_seconds, and, except for personal user tokens,
+// <prefix>_allow_permission_updates.
+var organizationTokenPolicies = []struct {
+	prefix            string
+	permissionUpdates bool
+	policy            func(*humio.OrganizationSettings) *humio.TokenSecurityPolicy
+}{
+	{"personal_user_tokens", false, func(s *humio.OrganizationSettings) *humio.TokenSecurityPolicy { return &s.PersonalUserTokens }},
+	{"view_permission_tokens", true, func(s *humio.OrganizationSettings) *humio.TokenSecurityPolicy { return &s.ViewPermissionTokens }},
+	{"organization_permission_tokens", true, func(s *humio.OrganizationSettings) *humio.TokenSecurityPolicy { return &s.OrganizationPermissionTokens }},
+	{"system_permission_tokens", true, func(s *humio.OrganizationSettings) *humio.TokenSecurityPolicy { return &s.SystemPermissionTokens }},
+}
+
+// millisecondsPerSecond converts between the token TTLs of the API and the seconds of the resource.
+const millisecondsPerSecond int64 = 1000
+
+func resourceOrganizationSettings() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"shared_dashboards_enabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Computed: true,
+		},
+		// The IP filter enforced on all shared dashboard links, regardless of the IP filter of the link itself.
+		"shared_dashboards_ip_filter_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"default_role_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+	}
+	for _, p := range organizationTokenPolicies {
+		s[p.prefix+"_enabled"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Computed: true,
+		}
+		// The max TTL is in seconds, which fits in the int of 32-bit platforms unlike the milliseconds of the API, and
+		// round-trips TTLs set in whole seconds. A max TTL of 0 allows tokens that never expire.
+		s[p.prefix+"_max_ttl_seconds"] = &schema.Schema{
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Computed:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+		}
+		if p.permissionUpdates {
+			s[p.prefix+"_allow_permission_updates"] = &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			}
+		}
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceOrganizationSettingsCreate,
+		ReadContext:   resourceOrganizationSettingsRead,
+		UpdateContext: resourceOrganizationSettingsUpdate,
+		DeleteContext: resourceOrganizationSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: s,
+	}
+}
+
+func resourceOrganizationSettingsCreate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	diags := updateConfiguredOrganizationSettings(d, client.(*humio.Client))
+	if diags != nil {
+		return diags
+	}
+	d.SetId(organizationSettingsID)
+
+	return resourceOrganizationSettingsRead(ctx, d, client)
+}
+
+func resourceOrganizationSettingsRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	settings, err := client.(*humio.Client).Organization().GetSettings()
+	if err != nil {
+		return diag.Errorf("could not get organization settings: %s", err)
+	}
+	return resourceDataFromOrganizationSettings(settings, d)
+}
+
+func resourceDataFromOrganizationSettings(a *humio.OrganizationSettings, d *schema.ResourceData) diag.Diagnostics {
+	for _, p := range organizationTokenPolicies {
+		policy := p.policy(a)
+		err := d.Set(p.prefix+"_enabled", policy.Enabled)
+		if err != nil {
+			return diag.Errorf("error setting %s_enabled for resource %s: %s", p.prefix, d.Id(), err)
+		}
+		err = d.Set(p.prefix+"_max_ttl_seconds", policy.MaxTTL/millisecondsPerSecond)
+		if err != nil {
+			return diag.Errorf("error setting %s_max_ttl_seconds for resource %s: %s", p.prefix, d.Id(), err)
+		}
+		if p.permissionUpdates {
+			err = d.Set(p.prefix+"_allow_permission_updates", policy.AllowPermissionUpdates)
+			if err != nil {
+				return diag.Errorf("error setting %s_allow_permission_updates for resource %s: %s", p.prefix, d.Id(), err)
+			}
+		}
+	}
+	err := d.Set("shared_dashboards_enabled", a.SharedDashboardsEnabled)
+	if err != nil {
+		return diag.Errorf("error setting shared_dashboards_enabled for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("shared_dashboards_ip_filter_id", a.SharedDashboardsIPFilterID)
+	if err != nil {
+		return diag.Errorf("error setting shared_dashboards_ip_filter_id for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("default_role_id", a.DefaultRoleID)
+	if err != nil {
+		return diag.Errorf("error setting default_role_id for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceOrganizationSettingsUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	diags := updateConfiguredOrganizationSettings(d, client.(*humio.Client))
+	if diags != nil {
+		return diags
+	}
+	return resourceOrganizationSettingsRead(ctx, d, client)
+}
+
+// updateConfiguredOrganizationSettings updates the organization settings that are set in the configuration. The
+// settings that are not are sent with their current values, so they are left as they are.
+func updateConfiguredOrganizationSettings(d *schema.ResourceData, client *humio.Client) diag.Diagnostics {
+	current, err := client.Organization().GetSettings()
+	if err != nil {
+		return diag.Errorf("could not get organization settings: %s", err)
+	}
+	settings, err := organizationSettingsFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain organization settings from resource data: %s", err)
+	}
+	settings = configuredOrganizationSettings(d, settings, *current)
+
+	_, err = client.Organization().UpdateSettings(
+		&settings,
+	)
+	if err != nil {
+		return diag.Errorf("could not update organization settings: %s", err)
+	}
+	return nil
+}
+
+// resourceOrganizationSettingsDelete only removes the settings from the state. Resetting security settings as a side
+// effect of e.g. moving them to another Terraform configuration would be worse than leaving them as they are.
+func resourceOrganizationSettingsDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func organizationSettingsFromResourceData(d *schema.ResourceData) (humio.OrganizationSettings, error) {
+	settings := humio.OrganizationSettings{
+		SharedDashboardsEnabled:    d.Get("shared_dashboards_enabled").(bool),
+		SharedDashboardsIPFilterID: d.Get("shared_dashboards_ip_filter_id").(string),
+		DefaultRoleID:              d.Get("default_role_id").(string),
+	}
+	for _, p := range organizationTokenPolicies {
+		policy := p.policy(&settings)
+		policy.Enabled = d.Get(p.prefix + "_enabled").(bool)
+		policy.MaxTTL = int64(d.Get(p.prefix+"_max_ttl_seconds").(int)) * millisecondsPerSecond
+		if p.permissionUpdates {
+			policy.AllowPermissionUpdates = d.Get(p.prefix + "_allow_permission_updates").(bool)
+		}
+	}
+	return settings, nil
+}
+
+// configuredOrganizationSettings returns current with the settings that are set in the configuration taken from
+// settings instead.
+func configuredOrganizationSettings(d *schema.ResourceData, settings, current humio.OrganizationSettings) humio.OrganizationSettings {
+	config := d.GetRawConfig()
+	configured := func(name string) bool {
+		return !config.IsNull() && !config.GetAttr(name).IsNull()
+	}
+
+	if configured("shared_dashboards_enabled") {
+		current.SharedDashboardsEnabled = settings.SharedDashboardsEnabled
+	}
+	if configured("shared_dashboards_ip_filter_id") {
+		current.SharedDashboardsIPFilterID = settings.SharedDashboardsIPFilterID
+	}
+	if configured("default_role_id") {
+		current.DefaultRoleID = settings.DefaultRoleID
+	}
+	for _, p := range organizationTokenPolicies {
+		policy, want := p.policy(&current), p.policy(&settings)
+		if configured(p.prefix + "_enabled") {
+			policy.Enabled = want.Enabled
+		}
+		if configured(p.prefix + "_max_ttl_seconds") {
+			policy.MaxTTL = want.MaxTTL
+		}
+		if p.permissionUpdates && configured(p.prefix+"_allow_permission_updates") {
+			policy.AllowPermissionUpdates = want.AllowPermissionUpdates
+		}
+	}
+	return current
+}
@@ -0,0 +1,145 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"regexp"
+	"testing"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccOrganizationSettingsInvalidSettings(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{Config: organizationSettingsInvalidSettings, ExpectError: regexp.MustCompile(`expected personal_user_tokens_max_ttl_seconds to be at least \(0\), got -1`)},
+	}, nil)
+}
+
+func TestAccOrganizationSettingsBasicToFull(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: organizationSettingsBasic,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_organization_settings.test", "id", "organization"),
+				resource.TestCheckResourceAttr("humio_organization_settings.test", "personal_user_tokens_enabled", "true"),
+				resource.TestCheckResourceAttr("humio_organization_settings.test", "personal_user_tokens_max_ttl_seconds", "0"),
+				resource.TestCheckResourceAttr("humio_organization_settings.test", "shared_dashboards_enabled", "true"),
+			),
+		},
+		{
+			Config: organizationSettingsFull,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_organization_settings.test", "personal_user_tokens_max_ttl_seconds", "2592000"),
+				resource.TestCheckResourceAttr("humio_organization_settings.test", "view_permission_tokens_max_ttl_seconds", "7776000"),
+				resource.TestCheckResourceAttr("humio_organization_settings.test", "view_permission_tokens_allow_permission_updates", "false"),
+				resource.TestCheckResourceAttr("humio_organization_settings.test", "system_permission_tokens_enabled", "false"),
+				resource.TestCheckResourceAttr("humio_organization_settings.test", "shared_dashboards_enabled", "false"),
+			),
+		},
+		{
+			ResourceName:      "humio_organization_settings.test",
+			ImportState:       true,
+			ImportStateId:     "organization",
+			ImportStateVerify: true,
+		},
+		{
+			// Settings that are no longer configured are left as they are.
+			Config: organizationSettingsPartial,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_organization_settings.test", "personal_user_tokens_max_ttl_seconds", "2592000"),
+				resource.TestCheckResourceAttr("humio_organization_settings.test", "system_permission_tokens_enabled", "false"),
+				resource.TestCheckResourceAttr("humio_organization_settings.test", "shared_dashboards_enabled", "true"),
+			),
+		},
+		{
+			// Leave the settings as we found them for the other tests.
+			Config: organizationSettingsDefaults,
+		},
+	}, nil)
+}
+
+const organizationSettingsInvalidSettings = `
+resource "humio_organization_settings" "test" {
+    personal_user_tokens_max_ttl_seconds = -1
+}
+`
+
+const organizationSettingsBasic = `
+resource "humio_organization_settings" "test" {}
+`
+
+const organizationSettingsFull = `
+resource "humio_organization_settings" "test" {
+    personal_user_tokens_max_ttl_seconds            = 2592000
+    view_permission_tokens_max_ttl_seconds          = 7776000
+    view_permission_tokens_allow_permission_updates = false
+    system_permission_tokens_enabled                = false
+    shared_dashboards_enabled                       = false
+}
+`
+
+const organizationSettingsPartial = `
+resource "humio_organization_settings" "test" {
+    shared_dashboards_enabled = true
+}
+`
+
+const organizationSettingsDefaults = `
+resource "humio_organization_settings" "test" {
+    personal_user_tokens_max_ttl_seconds            = 0
+    view_permission_tokens_max_ttl_seconds          = 0
+    view_permission_tokens_allow_permission_updates = true
+    system_permission_tokens_enabled                = true
+    shared_dashboards_enabled                       = true
+}
+`
+
+var wantOrganizationSettings = humio.OrganizationSettings{
+	PersonalUserTokens: humio.TokenSecurityPolicy{
+		Enabled: true,
+		MaxTTL:  2592000000,
+	},
+	ViewPermissionTokens: humio.TokenSecurityPolicy{
+		Enabled:                true,
+		MaxTTL:                 7776000000,
+		AllowPermissionUpdates: false,
+	},
+	OrganizationPermissionTokens: humio.TokenSecurityPolicy{
+		Enabled:                true,
+		AllowPermissionUpdates: true,
+	},
+	SystemPermissionTokens: humio.TokenSecurityPolicy{
+		Enabled: false,
+	},
+	SharedDashboardsEnabled:    true,
+	SharedDashboardsIPFilterID: "abcdef",
+	DefaultRoleID:              "ghijkl",
+}
+
+func TestEncodeDecodeOrganizationSettingsResource(t *testing.T) {
+	res := resourceOrganizationSettings()
+	data := res.TestResourceData()
+	resourceDataFromOrganizationSettings(&wantOrganizationSettings, data)
+	got, err := organizationSettingsFromResourceData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantOrganizationSettings, got) {
+		t.Error(cmp.Diff(wantOrganizationSettings, got))
+	}
+}
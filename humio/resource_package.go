@@ -0,0 +1,423 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+const packageManifestFile = "manifest.yaml"
+
+func resourcePackage() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePackageCreate,
+		ReadContext:   resourcePackageRead,
+		UpdateContext: resourcePackageUpdate,
+		DeleteContext: resourcePackageDelete,
+		CustomizeDiff: resourcePackageCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"view": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"path": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"package_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+				ForceNew: true,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"content_hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"assets": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// resourcePackageCustomizeDiff reads the local package so the plan shows the package version, and which assets will be
+// replaced, whenever the package differs from the installed package read from the server.
+func resourcePackageCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("path") {
+		for _, key := range []string{"package_id", "version", "content_hash", "assets"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	archive, err := readPackageArchive(d.Get("path").(string))
+	if err != nil {
+		return err
+	}
+
+	if err := d.SetNew("package_id", archive.ID); err != nil {
+		return err
+	}
+	if err := d.SetNew("version", archive.Version); err != nil {
+		return err
+	}
+	if err := d.SetNew("content_hash", archive.ContentHash); err != nil {
+		return err
+	}
+	return d.SetNew("assets", archive.Assets)
+}
+
+func resourcePackageCreate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	archive, err := readPackageArchive(d.Get("path").(string))
+	if err != nil {
+		return diag.Errorf("could not read package: %s", err)
+	}
+
+	err = client.(*humio.Client).Packages().Install(
+		d.Get("view").(string),
+		archive.Zip,
+	)
+	if err != nil {
+		return diag.Errorf("could not install package: %s", err)
+	}
+	d.SetId(fmt.Sprintf("%s+%s", d.Get("view"), archive.ID))
+
+	return resourcePackageRead(ctx, d, client)
+}
+
+func resourcePackageRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	parts := parseRepositoryAndID(d.Id())
+	//we check that we have parsed the id into the correct number of segments
+	if parts[0] == "" || parts[1] == "" {
+		return diag.Errorf("error importing humio_package. Please make sure the ID is in the form VIEWNAME+PACKAGEID (i.e. myViewName+myscope/mypackage")
+	}
+	// If we don't have a view when importing, we parse it from the ID.
+	if _, ok := d.GetOk("view"); !ok {
+		err := d.Set("view", parts[0])
+		if err != nil {
+			return diag.Errorf("error setting view for resource %s: %s", d.Id(), err)
+		}
+	}
+
+	pkg, err := client.(*humio.Client).Packages().Get(
+		d.Get("view").(string),
+		parts[1],
+	)
+	if err != nil {
+		return diag.Errorf("could not get package: %s", err)
+	}
+	return resourceDataFromInstalledPackage(pkg, d)
+}
+
+func resourceDataFromInstalledPackage(a *humio.InstalledPackage, d *schema.ResourceData) diag.Diagnostics {
+	assets := map[string]string{}
+	for name, content := range a.Assets {
+		assetHash, err := packageAssetHash(name, []byte(content))
+		if err != nil {
+			return diag.Errorf("could not hash installed asset of resource %s: %s", d.Id(), err)
+		}
+		assets[name] = assetHash
+	}
+
+	err := d.Set("package_id", a.ID)
+	if err != nil {
+		return diag.Errorf("error setting package_id for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("version", a.Version)
+	if err != nil {
+		return diag.Errorf("error setting version for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("content_hash", packageContentHash(a.Version, assets))
+	if err != nil {
+		return diag.Errorf("error setting content_hash for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("assets", assets)
+	if err != nil {
+		return diag.Errorf("error setting assets for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourcePackageUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	archive, err := readPackageArchive(d.Get("path").(string))
+	if err != nil {
+		return diag.Errorf("could not read package: %s", err)
+	}
+
+	if d.HasChanges("content_hash", "version") {
+		err = client.(*humio.Client).Packages().Install(
+			d.Get("view").(string),
+			archive.Zip,
+		)
+		if err != nil {
+			return diag.Errorf("could not upgrade package: %s", err)
+		}
+	}
+
+	return resourcePackageRead(ctx, d, client)
+}
+
+func resourcePackageDelete(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	err := client.(*humio.Client).Packages().Uninstall(
+		d.Get("view").(string),
+		d.Get("package_id").(string),
+	)
+	if err != nil {
+		return diag.Errorf("could not uninstall package: %s", err)
+	}
+	return nil
+}
+
+// packageArchive is a package read from a local zip archive or directory.
+type packageArchive struct {
+	ID          string
+	Version     string
+	ContentHash string
+	Assets      map[string]string
+	Zip         []byte
+}
+
+// packageManifest is the subset of a package's manifest.yaml that we care about.
+type packageManifest struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+}
+
+// readPackageArchive reads the package at the given path, which is either a zip archive or a directory containing a
+// manifest.yaml. Directories are zipped in memory so they can be uploaded the same way as archives.
+func readPackageArchive(packagePath string) (*packageArchive, error) {
+	info, err := os.Stat(packagePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var files map[string][]byte
+	var zipped []byte
+	if info.IsDir() {
+		files, err = readPackageDirectory(packagePath)
+		if err != nil {
+			return nil, err
+		}
+		zipped, err = zipPackageFiles(files)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		zipped, err = os.ReadFile(packagePath)
+		if err != nil {
+			return nil, err
+		}
+		files, err = readPackageZip(zipped)
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s as a zip archive: %w", packagePath, err)
+		}
+	}
+
+	rawManifest, ok := files[packageManifestFile]
+	if !ok {
+		return nil, fmt.Errorf("no %s found in %s", packageManifestFile, packagePath)
+	}
+	var manifest packageManifest
+	if err := yaml.Unmarshal(rawManifest, &manifest); err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", packageManifestFile, err)
+	}
+	if manifest.Name == "" || manifest.Version == "" {
+		return nil, fmt.Errorf("%s must specify both name and version", packageManifestFile)
+	}
+
+	assets := map[string]string{}
+	for name, content := range files {
+		dir := path.Dir(name)
+		if !packageAssetDirectories[dir] {
+			continue
+		}
+		if ext := path.Ext(name); dir != "lookupFiles" && ext == ".yml" {
+			name = strings.TrimSuffix(name, ext) + ".yaml"
+		}
+		fileHash, err := packageAssetHash(name, content)
+		if err != nil {
+			return nil, err
+		}
+		assets[name] = fileHash
+	}
+
+	return &packageArchive{
+		ID:          manifest.Name,
+		Version:     manifest.Version,
+		ContentHash: packageContentHash(manifest.Version, assets),
+		Assets:      assets,
+		Zip:         zipped,
+	}, nil
+}
+
+// packageAssetDirectories holds the directories of the assets of a package that can be read back from the server, and
+// so are compared with the installed package. Other files such as a README do not make the package differ.
+var packageAssetDirectories = map[string]bool{
+	"dashboards":        true,
+	"queries":           true,
+	"parsers":           true,
+	"alerts":            true,
+	"actions":           true,
+	"scheduledSearches": true,
+	"lookupFiles":       true,
+}
+
+// packageAssetHash hashes an asset of a package. YAML assets are hashed by their contents rather than their bytes, as
+// the server does not necessarily return them formatted the way they were installed.
+func packageAssetHash(name string, content []byte) (string, error) {
+	if path.Dir(name) != "lookupFiles" {
+		var template interface{}
+		if err := yaml.Unmarshal(content, &template); err != nil {
+			return "", fmt.Errorf("could not parse %s: %w", name, err)
+		}
+		canonical, err := json.Marshal(template)
+		if err != nil {
+			return "", fmt.Errorf("could not encode %s: %w", name, err)
+		}
+		content = canonical
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// packageContentHash combines the version and asset hashes of a package, so it can be computed for both a local
+// package and an installed one.
+func packageContentHash(version string, assets map[string]string) string {
+	names := make([]string, 0, len(assets))
+	for name := range assets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	contentHash := sha256.New()
+	fmt.Fprintf(contentHash, "%s\n", version)
+	for _, name := range names {
+		fmt.Fprintf(contentHash, "%s\x00%s\n", name, assets[name])
+	}
+	return hex.EncodeToString(contentHash.Sum(nil))
+}
+
+func readPackageDirectory(root string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	err := filepath.WalkDir(root, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = content
+		return nil
+	})
+	return files, err
+}
+
+// readPackageZip returns the files of a zipped package keyed by their path relative to the manifest, as archives
+// frequently wrap the package in a top-level directory.
+func readPackageZip(zipped []byte) (map[string][]byte, error) {
+	reader, err := zip.NewReader(bytes.NewReader(zipped), int64(len(zipped)))
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, found := "", false
+	for _, f := range reader.File {
+		if path.Base(f.Name) == packageManifestFile {
+			dir := strings.TrimSuffix(f.Name, packageManifestFile)
+			if !found || len(dir) < len(prefix) {
+				prefix, found = dir, true
+			}
+		}
+	}
+
+	files := map[string][]byte{}
+	for _, f := range reader.File {
+		if f.FileInfo().IsDir() || !strings.HasPrefix(f.Name, prefix) {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		files[strings.TrimPrefix(f.Name, prefix)] = content
+	}
+	return files, nil
+}
+
+func zipPackageFiles(files map[string][]byte) ([]byte, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf := &bytes.Buffer{}
+	writer := zip.NewWriter(buf)
+	for _, name := range names {
+		w, err := writer.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(files[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,199 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"testing"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPackageRequiredFields(t *testing.T) {
+	config := packageEmpty
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "view" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "path" is required, but no definition was found.`)},
+	}, nil)
+}
+
+func TestAccPackageMissingPath(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{Config: packageMissingPath, ExpectError: regexp.MustCompile(`no such file or directory`)},
+	}, nil)
+}
+
+func TestAccPackageMissingManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeTestPackage(t, dir, map[string]string{"parsers/accesslog.yaml": "name: accesslog\n"})
+	accTestCase(t, []resource.TestStep{
+		{Config: fmt.Sprintf(packageAtPath, dir), ExpectError: regexp.MustCompile(`no manifest\.yaml found in`)},
+	}, nil)
+}
+
+const packageEmpty = `
+resource "humio_package" "test" {}
+`
+
+const packageMissingPath = `
+resource "humio_package" "test" {
+    view = "sandbox"
+    path = "/does/not/exist"
+}
+`
+
+const packageAtPath = `
+resource "humio_package" "test" {
+    view = "sandbox"
+    path = %q
+}
+`
+
+var testPackageFiles = map[string]string{
+	"manifest.yaml":          "name: clearhaus/test\nversion: 1.2.3\n",
+	"parsers/accesslog.yaml": "name: accesslog\nscript: kvParse()\n",
+	"dashboards/ops.yaml":    "name: ops\n",
+	"README.md":              "# Test package\n",
+}
+
+func writeTestPackage(t *testing.T, root string, files map[string]string) {
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReadPackageArchiveDirectoryAndZipMatch(t *testing.T) {
+	dir := t.TempDir()
+	writeTestPackage(t, dir, testPackageFiles)
+
+	fromDir, err := readPackageArchive(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromDir.ID != "clearhaus/test" || fromDir.Version != "1.2.3" {
+		t.Errorf("unexpected package id and version: %s %s", fromDir.ID, fromDir.Version)
+	}
+	wantAssets := []string{"dashboards/ops.yaml", "parsers/accesslog.yaml"}
+	var gotAssets []string
+	for name := range fromDir.Assets {
+		gotAssets = append(gotAssets, name)
+	}
+	if !cmp.Equal(wantAssets, gotAssets, cmp.Transformer("sort", sortedStrings)) {
+		t.Error(cmp.Diff(wantAssets, gotAssets))
+	}
+
+	zipPath := filepath.Join(t.TempDir(), "package.zip")
+	if err := os.WriteFile(zipPath, fromDir.Zip, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fromZip, err := readPackageArchive(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromDir.ContentHash != fromZip.ContentHash {
+		t.Errorf("content hash differs between directory and zip: %s != %s", fromDir.ContentHash, fromZip.ContentHash)
+	}
+	if !cmp.Equal(fromDir.Assets, fromZip.Assets) {
+		t.Error(cmp.Diff(fromDir.Assets, fromZip.Assets))
+	}
+}
+
+func TestReadPackageArchiveChangedAsset(t *testing.T) {
+	dir := t.TempDir()
+	writeTestPackage(t, dir, testPackageFiles)
+	before, err := readPackageArchive(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeTestPackage(t, dir, map[string]string{"parsers/accesslog.yaml": "name: accesslog\nscript: parseJson()\n"})
+	after, err := readPackageArchive(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before.ContentHash == after.ContentHash {
+		t.Error("expected content hash to change")
+	}
+	if before.Assets["parsers/accesslog.yaml"] == after.Assets["parsers/accesslog.yaml"] {
+		t.Error("expected hash of changed asset to change")
+	}
+	if before.Assets["dashboards/ops.yaml"] != after.Assets["dashboards/ops.yaml"] {
+		t.Error("expected hash of unchanged asset to stay the same")
+	}
+}
+
+func TestPackageArchiveMatchesInstalledPackage(t *testing.T) {
+	dir := t.TempDir()
+	writeTestPackage(t, dir, testPackageFiles)
+	archive, err := readPackageArchive(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The server returns the templates formatted its own way, and without files that are not assets.
+	installed := &humio.InstalledPackage{
+		ID:      "clearhaus/test",
+		Version: "1.2.3",
+		Assets: map[string]string{
+			"parsers/accesslog.yaml": "script: \"kvParse()\"\nname: \"accesslog\"\n",
+			"dashboards/ops.yaml":    "name: ops",
+		},
+	}
+	data := resourcePackage().TestResourceData()
+	if diags := resourceDataFromInstalledPackage(installed, data); diags != nil {
+		t.Fatal(diags)
+	}
+	if got := data.Get("content_hash").(string); got != archive.ContentHash {
+		t.Errorf("content hash of installed package %s differs from archive %s", got, archive.ContentHash)
+	}
+
+	installed.Assets["parsers/accesslog.yaml"] = "name: accesslog\nscript: parseJson()\n"
+	if diags := resourceDataFromInstalledPackage(installed, data); diags != nil {
+		t.Fatal(diags)
+	}
+	if got := data.Get("content_hash").(string); got == archive.ContentHash {
+		t.Error("expected content hash of changed installed package to differ from archive")
+	}
+	if got := data.Get("assets").(map[string]interface{})["parsers/accesslog.yaml"]; got == archive.Assets["parsers/accesslog.yaml"] {
+		t.Error("expected hash of changed installed asset to differ from archive")
+	}
+}
+
+func TestReadPackageArchiveMissingManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeTestPackage(t, dir, map[string]string{"parsers/accesslog.yaml": "name: accesslog\n"})
+	if _, err := readPackageArchive(dir); err == nil {
+		t.Error("expected an error for a package without manifest.yaml")
+	}
+}
+
+func sortedStrings(in []string) []string {
+	out := append([]string(nil), in...)
+	sort.Strings(out)
+	return out
+}
@@ -16,11 +16,14 @@ package humio
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
 )
@@ -31,10 +34,227 @@ func resourceParser() *schema.Resource {
 		ReadContext:   resourceParserRead,
 		UpdateContext: resourceParserUpdate,
 		DeleteContext: resourceParserDelete,
+		CustomizeDiff: resourceParserCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceParserImport,
 		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceParserV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceParserStateUpgradeV0,
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"parser_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tag_fields": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"test_data": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"test_case"},
+			},
+			// test_case is the structured alternative to test_data, with assertions on the events the parser produces.
+			"test_case": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"test_data"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"event": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"assertions": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									// A parser may produce more than one event per test case.
+									"output_event_index": {
+										Type:             schema.TypeInt,
+										Optional:         true,
+										Default:          0,
+										ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+									},
+									"field_equals": {
+										Type:     schema.TypeMap,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+									"fields_absent": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"parser_script": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			// Fields removed from incoming events before the script runs. Left out, the fields set on the server are kept.
+			"fields_to_remove_before_parsing": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// allow_overwriting_existing_parser takes over a parser of the same name that already exists when creating
+			// the parser, rather than failing.
+			"allow_overwriting_existing_parser": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// run_tests_on_plan runs the test data through the planned parser script on the server during plan.
+			// Disable it when planning without access to the server.
+			"run_tests_on_plan": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+// resourceParserCustomizeDiff runs the test cases through the planned parser script on the server, so a broken parser
+// fails the plan rather than the ingest. A test case fails when the parser reports an error for it, when one of its
+// output events is missing a tag field, or when its assertions do not hold.
+func resourceParserCustomizeDiff(_ context.Context, d *schema.ResourceDiff, client interface{}) error {
+	if !d.Get("run_tests_on_plan").(bool) {
+		return nil
+	}
+	if !d.HasChanges("parser_script", "test_data", "test_case", "tag_fields", "fields_to_remove_before_parsing") {
+		return nil
+	}
+	for _, key := range []string{"repository", "parser_script", "test_data", "test_case", "tag_fields"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+	parser := humio.Parser{
+		Script:      d.Get("parser_script").(string),
+		FieldsToTag: convertInterfaceListToStringSlice(d.Get("tag_fields").([]interface{})),
+		TestCases:   parserTestCasesFromLists(d.Get("test_data").([]interface{}), d.Get("test_case").([]interface{})),
+	}
+	if len(parser.TestCases) == 0 {
+		return nil
+	}
+	// Fields to remove that are not known yet, such as when left out for a new parser, are tested as none.
+	if d.NewValueKnown("fields_to_remove_before_parsing") {
+		parser.FieldsToBeRemovedBeforeParsing = convertInterfaceListToStringSlice(d.Get("fields_to_remove_before_parsing").([]interface{}))
+	}
+	// Point the errors at the attribute the test cases were configured with.
+	testCaseAttribute := "test_data"
+	if len(d.Get("test_case").([]interface{})) > 0 {
+		testCaseAttribute = "test_case"
+	}
+
+	results, err := client.(*humio.Client).Parsers().Test(d.Get("repository").(string), &parser)
+	if err != nil {
+		// The repository may be created in the same apply as the parser, in which case the tests run on the next plan.
+		if exists, listErr := repositoryExists(client.(*humio.Client), d.Get("repository").(string)); listErr == nil && !exists {
+			return nil
+		}
+		return fmt.Errorf("could not run parser tests: %s", err)
+	}
+
+	var errs []error
+	for i, result := range results {
+		for _, event := range result.OutputEvents {
+			if event["@error"] == "true" {
+				errs = append(errs, fmt.Errorf("%s.%d: parser failed: %s", testCaseAttribute, i, event["@error_msg"]))
+				continue
+			}
+			for _, field := range parser.FieldsToTag {
+				if _, ok := event[field]; !ok {
+					errs = append(errs, fmt.Errorf("%s.%d: output is missing tag field %q", testCaseAttribute, i, field))
+				}
+			}
+		}
+		for _, err := range checkParserTestCaseAssertions(parser.TestCases[i].OutputAssertions, result.OutputEvents) {
+			errs = append(errs, fmt.Errorf("%s.%d: %s", testCaseAttribute, i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// repositoryExists reports whether a repository of the given name exists on the server.
+func repositoryExists(client *humio.Client, name string) (bool, error) {
+	repositories, err := client.Repositories().List()
+	if err != nil {
+		return false, err
+	}
+	for _, repository := range repositories {
+		if repository.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkParserTestCaseAssertions returns an error for every assertion that does not hold for the output events of a
+// test case.
+func checkParserTestCaseAssertions(outputAssertions []humio.ParserTestCaseAssertions, outputEvents []map[string]string) []error {
+	var errs []error
+	for _, assertions := range outputAssertions {
+		if assertions.OutputEventIndex >= len(outputEvents) {
+			errs = append(errs, fmt.Errorf("parser produced %d events, expected an event at index %d", len(outputEvents), assertions.OutputEventIndex))
+			continue
+		}
+		event := outputEvents[assertions.OutputEventIndex]
+
+		fieldNames := make([]string, 0, len(assertions.FieldsHaveValues))
+		for fieldName := range assertions.FieldsHaveValues {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		for _, fieldName := range fieldNames {
+			want := assertions.FieldsHaveValues[fieldName]
+			got, ok := event[fieldName]
+			if !ok {
+				errs = append(errs, fmt.Errorf("output event %d is missing field %q, expected %q", assertions.OutputEventIndex, fieldName, want))
+			} else if got != want {
+				errs = append(errs, fmt.Errorf("output event %d has field %q = %q, expected %q", assertions.OutputEventIndex, fieldName, got, want))
+			}
+		}
+		for _, fieldName := range assertions.FieldsNotPresent {
+			if _, ok := event[fieldName]; ok {
+				errs = append(errs, fmt.Errorf("output event %d has field %q, expected it to be absent", assertions.OutputEventIndex, fieldName))
+			}
+		}
+	}
+	return errs
+}
 
+// resourceParserV0 is the schema from before parsers were keyed by ID, when the resource ID was
+// REPOSITORYNAME+PARSERNAME.
+func resourceParserV0() *schema.Resource {
+	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"repository": {
 				Type:     schema.TypeString,
@@ -63,47 +283,105 @@ func resourceParser() *schema.Resource {
 	}
 }
 
+// resourceParserStateUpgradeV0 looks up the ID of the parser named in the state, so the parser can be tracked by ID.
+func resourceParserStateUpgradeV0(_ context.Context, rawState map[string]interface{}, client interface{}) (map[string]interface{}, error) {
+	humioClient, ok := client.(*humio.Client)
+	if !ok {
+		return nil, fmt.Errorf("the provider must be configured to upgrade humio_parser state")
+	}
+	repository, _ := rawState["repository"].(string)
+	name, _ := rawState["name"].(string)
+	// The Terraform-only attributes added since version 0 get their defaults, like on import.
+	rawState["run_tests_on_plan"] = true
+	rawState["allow_overwriting_existing_parser"] = false
+	parser, err := humioClient.Parsers().Get(repository, name)
+	if errors.Is(err, humio.ErrParserNotFound) {
+		// The parser was deleted outside of Terraform. The state is kept as it is, and the next read removes it.
+		return rawState, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get parser %s in repository %s: %w", name, repository, err)
+	}
+	rawState["id"] = fmt.Sprintf("%s+%s", repository, parser.ID)
+	rawState["parser_id"] = parser.ID
+	return rawState, nil
+}
+
+// resourceParserImport accepts both REPOSITORYNAME+PARSERID and REPOSITORYNAME+PARSERNAME, and sets the Terraform-only
+// attributes to their defaults, as they cannot be read from the parser, and would otherwise show up as a diff after
+// the import.
+func resourceParserImport(_ context.Context, d *schema.ResourceData, client interface{}) ([]*schema.ResourceData, error) {
+	parts := parseRepositoryAndID(d.Id())
+	//we check that we have parsed the id into the correct number of segments
+	if parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("error importing humio_parser. Please make sure the ID is in the form REPOSITORYNAME+PARSERID or REPOSITORYNAME+PARSERNAME (i.e. myRepoName+myParserName)")
+	}
+	parser, err := client.(*humio.Client).Parsers().GetByID(parts[0], parts[1])
+	if errors.Is(err, humio.ErrParserNotFound) {
+		parser, err = client.(*humio.Client).Parsers().Get(parts[0], parts[1])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not find parser with ID or name %s in repository %s: %w", parts[1], parts[0], err)
+	}
+	d.SetId(fmt.Sprintf("%s+%s", parts[0], parser.ID))
+
+	if err := d.Set("run_tests_on_plan", true); err != nil {
+		return nil, err
+	}
+	if err := d.Set("allow_overwriting_existing_parser", false); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceParserCreate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
 	parser, err := parserFromResourceData(d)
 	if err != nil {
 		return diag.Errorf("could not obtain parser from resource data: %s", err)
 	}
+	// Overwriting a parser keeps its fields to remove unless they are configured, like updating a parser does.
+	if d.Get("allow_overwriting_existing_parser").(bool) && d.GetRawConfig().GetAttr("fields_to_remove_before_parsing").IsNull() {
+		existing, err := client.(*humio.Client).Parsers().Get(d.Get("repository").(string), parser.Name)
+		if err != nil && !errors.Is(err, humio.ErrParserNotFound) {
+			return diag.Errorf("could not get existing parser: %s", err)
+		}
+		if existing != nil {
+			parser.FieldsToBeRemovedBeforeParsing = existing.FieldsToBeRemovedBeforeParsing
+		}
+	}
 
-	_, err = client.(*humio.Client).Parsers().Add(
+	created, err := client.(*humio.Client).Parsers().Add(
 		d.Get("repository").(string),
 		&parser,
-		false,
+		d.Get("allow_overwriting_existing_parser").(bool),
 	)
 	if err != nil {
 		return diag.Errorf("could not create parser: %s", err)
 	}
-	d.SetId(fmt.Sprintf("%s+%s", d.Get("repository"), d.Get("name")))
+	d.SetId(fmt.Sprintf("%s+%s", d.Get("repository"), created.ID))
 
 	return resourceParserRead(ctx, d, client)
 }
 
 func resourceParserRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	parts := parseRepositoryAndID(d.Id())
 	// If we don't have a repository when importing, we parse it from the ID.
 	if _, ok := d.GetOk("repository"); !ok {
-		parts := parseRepositoryAndID(d.Id())
-		//we check that we have parsed the id into the correct number of segments
-		if parts[0] == "" || parts[1] == "" {
-			return diag.Errorf("error importing humio_parser. Please make sure the ID is in the form REPOSITORYNAME+PARSERNAME (i.e. myRepoName+myParserName")
-		}
 		err := d.Set("repository", parts[0])
 		if err != nil {
 			return diag.Errorf("error setting repository for resource %s: %s", d.Id(), err)
 		}
-		err = d.Set("name", parts[1])
-		if err != nil {
-			return diag.Errorf("error setting name for resource %s: %s", d.Id(), err)
-		}
 	}
 
-	parser, err := client.(*humio.Client).Parsers().Get(
-		d.Get("repository").(string),
-		d.Get("name").(string),
+	parser, err := client.(*humio.Client).Parsers().GetByID(
+		parts[0],
+		parts[1],
 	)
+	if errors.Is(err, humio.ErrParserNotFound) {
+		// The parser was deleted outside of Terraform, so it is planned to be created again.
+		d.SetId("")
+		return nil
+	}
 	if err != nil || reflect.DeepEqual(*parser, humio.Parser{TestCases: []humio.ParserTestCase{}}) {
 		return diag.Errorf("could not get parser: %s", err)
 	}
@@ -115,6 +393,10 @@ func resourceDataFromParser(a *humio.Parser, d *schema.ResourceData) diag.Diagno
 	if err != nil {
 		return diag.Errorf("error setting name for resource %s: %s", d.Id(), err)
 	}
+	err = d.Set("parser_id", a.ID)
+	if err != nil {
+		return diag.Errorf("error setting parser_id for resource %s: %s", d.Id(), err)
+	}
 	err = d.Set("parser_script", a.Script)
 	if err != nil {
 		return diag.Errorf("error setting parser_script for resource %s: %s", d.Id(), err)
@@ -123,32 +405,65 @@ func resourceDataFromParser(a *humio.Parser, d *schema.ResourceData) diag.Diagno
 	if err != nil {
 		return diag.Errorf("error setting tag_fields for resource %s: %s", d.Id(), err)
 	}
-	var tests []string
+	err = d.Set("fields_to_remove_before_parsing", a.FieldsToBeRemovedBeforeParsing)
+	if err != nil {
+		return diag.Errorf("error setting fields_to_remove_before_parsing for resource %s: %s", d.Id(), err)
+	}
+	// Test cases are kept in test_case when configured that way, or when they carry assertions test_data cannot
+	// hold, and in test_data otherwise.
+	_, useTestCaseBlocks := d.GetOk("test_case")
 	for _, testCase := range a.TestCases {
-		tests = append(tests, testCase.Event.RawString)
+		if len(testCase.OutputAssertions) > 0 {
+			useTestCaseBlocks = true
+		}
+	}
+	var tests []string
+	var testCases []tfMap
+	if useTestCaseBlocks {
+		testCases = testCasesFromParser(a)
+	} else {
+		for _, testCase := range a.TestCases {
+			tests = append(tests, testCase.Event.RawString)
+		}
 	}
 	err = d.Set("test_data", tests)
 	if err != nil {
 		return diag.Errorf("error setting test_data for resource %s: %s", d.Id(), err)
 	}
+	err = d.Set("test_case", testCases)
+	if err != nil {
+		return diag.Errorf("error setting test_case for resource %s: %s", d.Id(), err)
+	}
 	return nil
 }
 
+func testCasesFromParser(a *humio.Parser) []tfMap {
+	testCases := make([]tfMap, len(a.TestCases))
+	for i, testCase := range a.TestCases {
+		assertions := make([]tfMap, len(testCase.OutputAssertions))
+		for j, outputAssertions := range testCase.OutputAssertions {
+			assertions[j] = tfMap{
+				"output_event_index": outputAssertions.OutputEventIndex,
+				"field_equals":       outputAssertions.FieldsHaveValues,
+				"fields_absent":      outputAssertions.FieldsNotPresent,
+			}
+		}
+		testCases[i] = tfMap{
+			"event":      testCase.Event.RawString,
+			"assertions": assertions,
+		}
+	}
+	return testCases
+}
+
 func resourceParserUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
 	parser, err := parserFromResourceData(d)
 	if err != nil {
 		return diag.Errorf("could not obtain parser from resource data: %s", err)
 	}
 
-	// Get existing parser to obtain its ID
-	existingParser, err := client.(*humio.Client).Parsers().Get(
-		d.Get("repository").(string),
-		d.Get("name").(string),
-	)
-	if err != nil {
-		return diag.Errorf("could not get existing parser for update: %s", err)
-	}
-	parser.ID = existingParser.ID
+	// The parser is updated by ID, so a changed name renames it.
+	parser.ID = parseRepositoryAndID(d.Id())[1]
 
 	_, err = client.(*humio.Client).Parsers().Update(
 		d.Get("repository").(string),
@@ -161,33 +476,61 @@ func resourceParserUpdate(ctx context.Context, d *schema.ResourceData, client in
 }
 
 func parserFromResourceData(d *schema.ResourceData) (humio.Parser, error) {
-	testData := convertInterfaceListToStringSlice(d.Get("test_data").([]interface{}))
+	return humio.Parser{
+		Name:                           d.Get("name").(string),
+		Script:                         d.Get("parser_script").(string),
+		FieldsToTag:                    convertInterfaceListToStringSlice(d.Get("tag_fields").([]interface{})),
+		FieldsToBeRemovedBeforeParsing: convertInterfaceListToStringSlice(d.Get("fields_to_remove_before_parsing").([]interface{})),
+		TestCases:                      parserTestCasesFromLists(d.Get("test_data").([]interface{}), d.Get("test_case").([]interface{})),
+	}, nil
+}
+
+// parserTestCasesFromLists builds the test cases from either the test_data or the test_case attribute, which conflict
+// with each other.
+func parserTestCasesFromLists(testData []interface{}, testCaseBlocks []interface{}) []humio.ParserTestCase {
 	var testCases []humio.ParserTestCase
-	for _, testString := range testData {
+	for _, testString := range convertInterfaceListToStringSlice(testData) {
 		testCases = append(testCases, humio.ParserTestCase{
 			Event: humio.ParserTestEvent{
 				RawString: testString,
 			},
 		})
 	}
-
-	return humio.Parser{
-		Name:        d.Get("name").(string),
-		Script:      d.Get("parser_script").(string),
-		FieldsToTag: convertInterfaceListToStringSlice(d.Get("tag_fields").([]interface{})),
-		TestCases:   testCases,
-	}, nil
+	for _, block := range testCaseBlocks {
+		testCase := block.(tfMap)
+		parserTestCase := humio.ParserTestCase{
+			Event: humio.ParserTestEvent{
+				RawString: testCase["event"].(string),
+			},
+		}
+		for _, assertionsBlock := range testCase["assertions"].([]interface{}) {
+			assertions, ok := assertionsBlock.(tfMap)
+			if !ok {
+				continue
+			}
+			outputAssertions := humio.ParserTestCaseAssertions{
+				OutputEventIndex: assertions["output_event_index"].(int),
+				FieldsNotPresent: convertInterfaceListToStringSlice(assertions["fields_absent"].([]interface{})),
+			}
+			fieldEquals := assertions["field_equals"].(tfMap)
+			if len(fieldEquals) > 0 {
+				outputAssertions.FieldsHaveValues = make(map[string]string, len(fieldEquals))
+				for fieldName, value := range fieldEquals {
+					outputAssertions.FieldsHaveValues[fieldName] = value.(string)
+				}
+			}
+			parserTestCase.OutputAssertions = append(parserTestCase.OutputAssertions, outputAssertions)
+		}
+		testCases = append(testCases, parserTestCase)
+	}
+	return testCases
 }
 
 func resourceParserDelete(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
-	parser, err := parserFromResourceData(d)
-	if err != nil {
-		return diag.Errorf("could not obtain parser from resource data: %s", err)
-	}
-
-	err = client.(*humio.Client).Parsers().Delete(
-		d.Get("repository").(string),
-		parser.Name,
+	parts := parseRepositoryAndID(d.Id())
+	err := client.(*humio.Client).Parsers().DeleteByID(
+		parts[0],
+		parts[1],
 	)
 	if err != nil {
 		return diag.Errorf("could not delete parser: %s", err)
@@ -15,7 +15,12 @@
 package humio
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"regexp"
 	"testing"
@@ -125,6 +130,179 @@ func TestAccParserFull(t *testing.T) {
 	}, testAccCheckParserDestroy)
 }
 
+func TestAccParserTestsOnPlan(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{Config: parserTestsFailing, ExpectError: regexp.MustCompile(`test_data\.1: parser failed`)},
+		{Config: parserTestsMissingTagField, ExpectError: regexp.MustCompile(`test_data\.1: output is missing tag field "service"`)},
+		{
+			Config: parserTestsPassing,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_parser.test", "run_tests_on_plan", "true"),
+				resource.TestCheckResourceAttr("humio_parser.test", "test_data.#", "1"),
+			),
+		},
+	}, testAccCheckParserDestroy)
+}
+
+func TestAccParserTestsOnPlanWithNewRepository(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: parserTestsNewRepository,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_parser.test", "repository", "parser-new-repository-test"),
+				resource.TestCheckResourceAttr("humio_parser.test", "test_data.#", "1"),
+			),
+		},
+	}, testAccCheckParserDestroy)
+}
+
+func TestAccParserTestCases(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{Config: parserTestCasesFailingAssertions, ExpectError: regexp.MustCompile(`test_case\.0: output event 0 has field "level" = "info", expected "debug"`)},
+		{
+			Config: parserTestCases,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_parser.test", "test_case.#", "2"),
+				resource.TestCheckResourceAttr("humio_parser.test", "test_case.0.event", "service=api level=info"),
+				resource.TestCheckResourceAttr("humio_parser.test", "test_case.0.assertions.0.output_event_index", "0"),
+				resource.TestCheckResourceAttr("humio_parser.test", "test_case.0.assertions.0.field_equals.level", "info"),
+				resource.TestCheckResourceAttr("humio_parser.test", "test_case.0.assertions.0.fields_absent.0", "@error"),
+				resource.TestCheckResourceAttr("humio_parser.test", "test_case.1.assertions.#", "0"),
+				resource.TestCheckNoResourceAttr("humio_parser.test", "test_data.#"),
+			),
+		},
+		{
+			ResourceName:      "humio_parser.test",
+			ImportState:       true,
+			ImportStateVerify: true,
+		},
+		{
+			Config: parserTestsPassing,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_parser.test", "test_data.#", "1"),
+				resource.TestCheckResourceAttr("humio_parser.test", "test_case.#", "0"),
+			),
+		},
+	}, testAccCheckParserDestroy)
+}
+
+// TestAccParserKeepsUnmanagedSettings checks that an apply does not wipe the fields to remove before parsing when they
+// are set outside of Terraform and left out of the configuration.
+func TestAccParserKeepsUnmanagedSettings(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: parserTestsPassing,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_parser.test", "fields_to_remove_before_parsing.#", "0"),
+			),
+		},
+		{
+			PreConfig: func() {
+				conn := testAccProviders["humio"].Meta().(*humio.Client)
+				parser, err := conn.Parsers().Get("sandbox", "parser-test")
+				if err != nil {
+					t.Fatal(err)
+				}
+				parser.FieldsToBeRemovedBeforeParsing = []string{"host", "source"}
+				if _, err := conn.Parsers().Update("sandbox", parser); err != nil {
+					t.Fatal(err)
+				}
+			},
+			Config: parserUnmanagedSettingsUpdated,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_parser.test", "parser_script", "kvParse() | service := lower(service)"),
+				resource.TestCheckResourceAttr("humio_parser.test", "fields_to_remove_before_parsing.#", "2"),
+				resource.TestCheckResourceAttr("humio_parser.test", "fields_to_remove_before_parsing.0", "host"),
+				resource.TestCheckResourceAttr("humio_parser.test", "fields_to_remove_before_parsing.1", "source"),
+				testAccCheckParserFieldsToRemove("sandbox", "parser-test", []string{"host", "source"}),
+			),
+		},
+		{
+			Config: parserFieldsToRemove,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_parser.test", "fields_to_remove_before_parsing.#", "1"),
+				testAccCheckParserFieldsToRemove("sandbox", "parser-test", []string{"host"}),
+			),
+		},
+		{
+			// Overwriting an existing parser keeps its fields to remove when they are left out.
+			PreConfig: func() {
+				conn := testAccProviders["humio"].Meta().(*humio.Client)
+				_, err := conn.Parsers().Add("sandbox", &humio.Parser{
+					Name:                           "parser-test-overwritten",
+					Script:                         "kvParse()",
+					FieldsToBeRemovedBeforeParsing: []string{"host", "source"},
+				}, false)
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			Config: parserFieldsToRemove + parserOverwritten,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_parser.overwritten", "parser_script", "kvParse() | service := lower(service)"),
+				resource.TestCheckResourceAttr("humio_parser.overwritten", "fields_to_remove_before_parsing.#", "2"),
+				testAccCheckParserFieldsToRemove("sandbox", "parser-test-overwritten", []string{"host", "source"}),
+			),
+		},
+	}, testAccCheckParserDestroy)
+}
+
+func TestAccParserRename(t *testing.T) {
+	var parserID string
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: parserBasic,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("humio_parser.test", "parser_id"),
+				func(s *terraform.State) error {
+					parserID = s.RootModule().Resources["humio_parser.test"].Primary.Attributes["parser_id"]
+					if want := "sandbox+" + parserID; s.RootModule().Resources["humio_parser.test"].Primary.ID != want {
+						return fmt.Errorf("expected ID %s, got %s", want, s.RootModule().Resources["humio_parser.test"].Primary.ID)
+					}
+					return nil
+				},
+			),
+		},
+		{
+			Config: parserRenamed,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_parser.test", "name", "parser-test-renamed"),
+				func(s *terraform.State) error {
+					if got := s.RootModule().Resources["humio_parser.test"].Primary.Attributes["parser_id"]; got != parserID {
+						return fmt.Errorf("expected the parser to be renamed in place, but its ID changed from %s to %s", parserID, got)
+					}
+					return nil
+				},
+			),
+		},
+		{
+			ResourceName:      "humio_parser.test",
+			ImportState:       true,
+			ImportStateVerify: true,
+		},
+		{
+			ResourceName:      "humio_parser.test",
+			ImportState:       true,
+			ImportStateId:     "sandbox+parser-test-renamed",
+			ImportStateVerify: true,
+		},
+	}, testAccCheckParserDestroy)
+}
+
+func testAccCheckParserFieldsToRemove(repository, name string, want []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProviders["humio"].Meta().(*humio.Client)
+		parser, err := conn.Parsers().Get(repository, name)
+		if err != nil {
+			return err
+		}
+		if !cmp.Equal(want, parser.FieldsToBeRemovedBeforeParsing) {
+			return fmt.Errorf("unexpected fields to remove before parsing: %s", cmp.Diff(want, parser.FieldsToBeRemovedBeforeParsing))
+		}
+		return nil
+	}
+}
+
 func testAccCheckParserDestroy(s *terraform.State) error {
 	conn := testAccProviders["humio"].Meta().(*humio.Client)
 
@@ -133,7 +311,7 @@ func testAccCheckParserDestroy(s *terraform.State) error {
 			continue
 		}
 		parts := parseRepositoryAndID(rs.Primary.ID)
-		resp, err := conn.Parsers().Get(parts[0], parts[1])
+		resp, err := conn.Parsers().GetByID(parts[0], parts[1])
 		emptyParser := humio.Parser{
 			Name:        "",
 			Script:      "",
@@ -169,6 +347,13 @@ resource "humio_parser" "test" {
 }
 `
 
+const parserRenamed = `
+resource "humio_parser" "test" {
+    repository = "sandbox"
+    name       = "parser-test-renamed"
+}
+`
+
 const parserFull = `
 resource "humio_parser" "test" {
     repository    = "sandbox"
@@ -176,6 +361,124 @@ resource "humio_parser" "test" {
     parser_script = "parser script here"
     tag_fields    = ["json","test"]
     test_data     = ["data1","data2"]
+
+    run_tests_on_plan = false
+}
+`
+
+const parserTestsFailing = `
+resource "humio_parser" "test" {
+    repository    = "sandbox"
+    name          = "parser-test"
+    parser_script = "kvParse() | parseTimestamp(field=ts)"
+    test_data     = ["ts=2024-01-01T00:00:00Z msg=ok", "msg=no timestamp"]
+}
+`
+
+const parserTestsMissingTagField = `
+resource "humio_parser" "test" {
+    repository    = "sandbox"
+    name          = "parser-test"
+    parser_script = "kvParse()"
+    tag_fields    = ["service"]
+    test_data     = ["service=api msg=ok", "msg=no service"]
+}
+`
+
+const parserUnmanagedSettingsUpdated = `
+resource "humio_parser" "test" {
+    repository    = "sandbox"
+    name          = "parser-test"
+    parser_script = "kvParse() | service := lower(service)"
+    tag_fields    = ["service"]
+    test_data     = ["service=api msg=ok"]
+}
+`
+
+const parserFieldsToRemove = `
+resource "humio_parser" "test" {
+    repository                      = "sandbox"
+    name                            = "parser-test"
+    parser_script                   = "kvParse()"
+    tag_fields                      = ["service"]
+    test_data                       = ["service=api msg=ok"]
+    fields_to_remove_before_parsing = ["host"]
+}
+`
+
+const parserOverwritten = `
+resource "humio_parser" "overwritten" {
+    repository                        = "sandbox"
+    name                              = "parser-test-overwritten"
+    parser_script                     = "kvParse() | service := lower(service)"
+    allow_overwriting_existing_parser = true
+}
+`
+
+const parserTestCasesFailingAssertions = `
+resource "humio_parser" "test" {
+    repository    = "sandbox"
+    name          = "parser-test"
+    parser_script = "kvParse()"
+
+    test_case {
+        event = "service=api level=info"
+        assertions {
+            field_equals = {
+                level = "debug"
+            }
+        }
+    }
+}
+`
+
+const parserTestCases = `
+resource "humio_parser" "test" {
+    repository    = "sandbox"
+    name          = "parser-test"
+    parser_script = "kvParse()"
+    tag_fields    = ["service"]
+
+    test_case {
+        event = "service=api level=info"
+        assertions {
+            field_equals = {
+                service = "api"
+                level   = "info"
+            }
+            fields_absent = ["@error"]
+        }
+    }
+
+    test_case {
+        event = "service=web"
+    }
+}
+`
+
+const parserTestsNewRepository = `
+resource "humio_repository" "test" {
+    name                = "parser-new-repository-test"
+    description         = "Created along with a parser"
+    deletion_protection = false
+}
+
+resource "humio_parser" "test" {
+    repository    = humio_repository.test.name
+    name          = "parser-test"
+    parser_script = "kvParse()"
+    tag_fields    = ["service"]
+    test_data     = ["service=api msg=ok"]
+}
+`
+
+const parserTestsPassing = `
+resource "humio_parser" "test" {
+    repository    = "sandbox"
+    name          = "parser-test"
+    parser_script = "kvParse()"
+    tag_fields    = ["service"]
+    test_data     = ["service=api msg=ok"]
 }
 `
 
@@ -198,3 +501,109 @@ func TestEncodeDecodeParserResource(t *testing.T) {
 		t.Error(cmp.Diff(wantParser, got))
 	}
 }
+
+var wantParserWithTestCases = humio.Parser{
+	Name:   "test-parser",
+	Script: "kvParse()",
+	TestCases: []humio.ParserTestCase{
+		{
+			Event: humio.ParserTestEvent{RawString: "service=api level=info"},
+			OutputAssertions: []humio.ParserTestCaseAssertions{
+				{
+					OutputEventIndex: 0,
+					FieldsHaveValues: map[string]string{"service": "api", "level": "info"},
+					FieldsNotPresent: []string{"@error"},
+				},
+			},
+		},
+		{
+			Event: humio.ParserTestEvent{RawString: "service=web"},
+		},
+	},
+	FieldsToTag:                    []string{"service"},
+	FieldsToBeRemovedBeforeParsing: []string{"host"},
+}
+
+func TestEncodeDecodeParserResourceWithTestCases(t *testing.T) {
+	res := resourceParser()
+	data := res.TestResourceData()
+	resourceDataFromParser(&wantParserWithTestCases, data)
+	got, err := parserFromResourceData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantParserWithTestCases, got) {
+		t.Error(cmp.Diff(wantParserWithTestCases, got))
+	}
+}
+
+func TestResourceParserStateUpgradeV0(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		if req.Variables["RepositoryName"] != "sandbox" {
+			t.Errorf("unexpected variables: %v", req.Variables)
+		}
+		if req.Variables["ParserName"] != "parser-test" {
+			fmt.Fprint(w, `{"data": {"repository": {"parser": null}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"data": {"repository": {"parser": {"id": "abc123", "name": "parser-test"}}}}`)
+	}))
+	defer server.Close()
+	address, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := humio.NewClient(humio.Config{Address: address, Token: "token"})
+
+	rawState := map[string]interface{}{
+		"id":            "sandbox+parser-test",
+		"repository":    "sandbox",
+		"name":          "parser-test",
+		"parser_script": "kvParse()",
+	}
+	got, err := resourceParserStateUpgradeV0(context.Background(), rawState, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"id":                                "sandbox+abc123",
+		"parser_id":                         "abc123",
+		"repository":                        "sandbox",
+		"name":                              "parser-test",
+		"parser_script":                     "kvParse()",
+		"run_tests_on_plan":                 true,
+		"allow_overwriting_existing_parser": false,
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+
+	// A parser deleted outside of Terraform keeps its state, so it can be read and planned to be created again.
+	rawState = map[string]interface{}{
+		"id":            "sandbox+parser-deleted",
+		"repository":    "sandbox",
+		"name":          "parser-deleted",
+		"parser_script": "kvParse()",
+	}
+	got, err = resourceParserStateUpgradeV0(context.Background(), rawState, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = map[string]interface{}{
+		"id":                                "sandbox+parser-deleted",
+		"repository":                        "sandbox",
+		"name":                              "parser-deleted",
+		"parser_script":                     "kvParse()",
+		"run_tests_on_plan":                 true,
+		"allow_overwriting_existing_parser": false,
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func resourceOrganizationPermissionToken() *schema.Resource {
+	return resourcePermissionToken(humio.PermissionTokenTypeOrganization)
+}
+
+func resourceViewPermissionToken() *schema.Resource {
+	return resourcePermissionToken(humio.PermissionTokenTypeView)
+}
+
+func resourceSystemPermissionToken() *schema.Resource {
+	return resourcePermissionToken(humio.PermissionTokenTypeSystem)
+}
+
+// resourcePermissionToken returns the resource for one type of permission token. The three types only differ in which
+// permissions they accept and in view tokens being scoped to a set of views.
+func resourcePermissionToken(tokenType string) *schema.Resource {
+	s := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"permissions": {
+			Type:     schema.TypeSet,
+			Required: true,
+			MinItems: 1,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"expire_at": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+			DiffSuppressFunc: suppressEquivalentRFC3339Time,
+		},
+		"ip_filter_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+		},
+		"token": {
+			Type:      schema.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+	}
+	if tokenType == humio.PermissionTokenTypeView {
+		s["views"] = &schema.Schema{
+			Type:     schema.TypeSet,
+			Required: true,
+			ForceNew: true,
+			MinItems: 1,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		}
+	}
+
+	return &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+			return resourcePermissionTokenCreate(ctx, d, client, tokenType)
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+			return resourcePermissionTokenRead(ctx, d, client, tokenType)
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+			return resourcePermissionTokenUpdate(ctx, d, client, tokenType)
+		},
+		DeleteContext: resourcePermissionTokenDelete,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, client interface{}) error {
+			return resourcePermissionTokenCustomizeDiff(ctx, d, client, tokenType)
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: s,
+	}
+}
+
+// resourcePermissionTokenCustomizeDiff checks the permissions against those the server accepts for the token type, so
+// a permission of another type of token fails the plan rather than the apply.
+func resourcePermissionTokenCustomizeDiff(_ context.Context, d *schema.ResourceDiff, client interface{}, tokenType string) error {
+	if !d.NewValueKnown("permissions") || !d.HasChange("permissions") {
+		return nil
+	}
+
+	validPermissions, err := client.(*humio.Client).PermissionTokens().ValidPermissions(tokenType)
+	if err != nil {
+		return fmt.Errorf("could not list valid permissions to validate permissions: %s", err)
+	}
+	valid := make(map[string]bool, len(validPermissions))
+	for _, permission := range validPermissions {
+		valid[permission] = true
+	}
+
+	var invalid []string
+	for _, permission := range convertInterfaceListToStringSlice(d.Get("permissions").(*schema.Set).List()) {
+		if !valid[permission] {
+			invalid = append(invalid, permission)
+		}
+	}
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return fmt.Errorf("permissions not valid for %s: %s", tokenType, strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+func resourcePermissionTokenCreate(ctx context.Context, d *schema.ResourceData, client interface{}, tokenType string) diag.Diagnostics {
+	permissionToken, err := permissionTokenFromResourceData(d, tokenType)
+	if err != nil {
+		return diag.Errorf("could not obtain permission token from resource data: %s", err)
+	}
+
+	t, secret, err := client.(*humio.Client).PermissionTokens().Add(
+		&permissionToken,
+	)
+	if err != nil {
+		return diag.Errorf("could not create permission token: %s", err)
+	}
+	d.SetId(t.ID)
+
+	// The secret is only ever returned here, so it is kept in state as is and never refreshed.
+	err = d.Set("token", secret)
+	if err != nil {
+		return diag.Errorf("error setting token for resource %s: %s", d.Id(), err)
+	}
+
+	return resourcePermissionTokenRead(ctx, d, client, tokenType)
+}
+
+func resourcePermissionTokenRead(_ context.Context, d *schema.ResourceData, client interface{}, tokenType string) diag.Diagnostics {
+	permissionToken, err := client.(*humio.Client).PermissionTokens().Get(
+		tokenType,
+		d.Id(),
+	)
+	if err != nil {
+		return diag.Errorf("could not get permission token: %s", err)
+	}
+	return resourceDataFromPermissionToken(permissionToken, d)
+}
+
+func resourceDataFromPermissionToken(a *humio.PermissionToken, d *schema.ResourceData) diag.Diagnostics {
+	err := d.Set("name", a.Name)
+	if err != nil {
+		return diag.Errorf("error setting name for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("permissions", a.Permissions)
+	if err != nil {
+		return diag.Errorf("error setting permissions for resource %s: %s", d.Id(), err)
+	}
+	if a.Type == humio.PermissionTokenTypeView {
+		err = d.Set("views", a.Views)
+		if err != nil {
+			return diag.Errorf("error setting views for resource %s: %s", d.Id(), err)
+		}
+	}
+	expireAt := ""
+	if a.ExpireAt > 0 {
+		expireAt = time.UnixMilli(a.ExpireAt).UTC().Format(time.RFC3339)
+	}
+	err = d.Set("expire_at", expireAt)
+	if err != nil {
+		return diag.Errorf("error setting expire_at for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("ip_filter_id", a.IPFilterID)
+	if err != nil {
+		return diag.Errorf("error setting ip_filter_id for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourcePermissionTokenUpdate(ctx context.Context, d *schema.ResourceData, client interface{}, tokenType string) diag.Diagnostics {
+	permissionToken, err := permissionTokenFromResourceData(d, tokenType)
+	if err != nil {
+		return diag.Errorf("could not obtain permission token from resource data: %s", err)
+	}
+
+	// Everything but the permissions forces a new token, as the API has no way of changing them.
+	err = client.(*humio.Client).PermissionTokens().UpdatePermissions(
+		&permissionToken,
+	)
+	if err != nil {
+		return diag.Errorf("could not update permission token: %s", err)
+	}
+	return resourcePermissionTokenRead(ctx, d, client, tokenType)
+}
+
+func resourcePermissionTokenDelete(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	err := client.(*humio.Client).PermissionTokens().Delete(
+		d.Id(),
+	)
+	if err != nil {
+		return diag.Errorf("could not delete permission token: %s", err)
+	}
+	return nil
+}
+
+func permissionTokenFromResourceData(d *schema.ResourceData, tokenType string) (humio.PermissionToken, error) {
+	var expireAt int64
+	if rawExpireAt := d.Get("expire_at").(string); rawExpireAt != "" {
+		t, err := time.Parse(time.RFC3339, rawExpireAt)
+		if err != nil {
+			return humio.PermissionToken{}, err
+		}
+		expireAt = t.UnixMilli()
+	}
+
+	permissions := convertInterfaceListToStringSlice(d.Get("permissions").(*schema.Set).List())
+	sort.Strings(permissions)
+
+	var views []string
+	if tokenType == humio.PermissionTokenTypeView {
+		views = convertInterfaceListToStringSlice(d.Get("views").(*schema.Set).List())
+		sort.Strings(views)
+	}
+
+	return humio.PermissionToken{
+		ID:          d.Id(),
+		Name:        d.Get("name").(string),
+		Type:        tokenType,
+		Permissions: permissions,
+		Views:       views,
+		ExpireAt:    expireAt,
+		IPFilterID:  d.Get("ip_filter_id").(string),
+	}, nil
+}
+
+// suppressEquivalentRFC3339Time suppresses diffs between timestamps describing the same instant, e.g. when the server
+// returns a time in UTC that was configured with an offset.
+func suppressEquivalentRFC3339Time(_, old, new string, _ *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
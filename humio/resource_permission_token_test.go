@@ -0,0 +1,212 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPermissionTokenRequiredFields(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{Config: permissionTokenEmpty, ExpectError: regexp.MustCompile(`The argument "name" is required, but no definition was found.`)},
+		{Config: permissionTokenEmpty, ExpectError: regexp.MustCompile(`The argument "permissions" is required, but no definition was found.`)},
+		{Config: viewPermissionTokenEmpty, ExpectError: regexp.MustCompile(`The argument "views" is required, but no definition was found.`)},
+	}, nil)
+}
+
+func TestAccPermissionTokenInvalidSettings(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{Config: permissionTokenInvalidSettings, ExpectError: regexp.MustCompile(`expected "expire_at" to be a valid RFC3339 date, got "tomorrow"`)},
+		{Config: permissionTokenInvalidPermissions, ExpectError: regexp.MustCompile(`permissions not valid for SystemPermissionToken: ReadAccess`)},
+	}, nil)
+}
+
+func TestAccOrganizationPermissionTokenBasicToFull(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: organizationPermissionTokenBasic,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_organization_permission_token.test", "name", "organization-permission-token-test"),
+				resource.TestCheckResourceAttr("humio_organization_permission_token.test", "permissions.#", "1"),
+				resource.TestCheckResourceAttr("humio_organization_permission_token.test", "expire_at", ""),
+				resource.TestCheckResourceAttrSet("humio_organization_permission_token.test", "token"),
+			),
+		},
+		{
+			Config: organizationPermissionTokenFull,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_organization_permission_token.test", "name", "organization-permission-token-test"),
+				resource.TestCheckResourceAttr("humio_organization_permission_token.test", "permissions.#", "2"),
+				resource.TestCheckTypeSetElemAttr("humio_organization_permission_token.test", "permissions.*", "CreateRepository"),
+				resource.TestCheckTypeSetElemAttr("humio_organization_permission_token.test", "permissions.*", "ViewUsage"),
+				resource.TestCheckResourceAttrSet("humio_organization_permission_token.test", "token"),
+			),
+		},
+	}, testAccCheckPermissionTokenDestroy)
+}
+
+func TestAccViewPermissionTokenFull(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: viewPermissionTokenFull,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_view_permission_token.test", "name", "view-permission-token-test"),
+				resource.TestCheckResourceAttr("humio_view_permission_token.test", "views.#", "1"),
+				resource.TestCheckTypeSetElemAttr("humio_view_permission_token.test", "views.*", "sandbox"),
+				resource.TestCheckTypeSetElemAttr("humio_view_permission_token.test", "permissions.*", "ReadAccess"),
+				resource.TestCheckResourceAttr("humio_view_permission_token.test", "expire_at", "2099-01-01T00:00:00Z"),
+				resource.TestCheckResourceAttrSet("humio_view_permission_token.test", "token"),
+			),
+		},
+	}, testAccCheckPermissionTokenDestroy)
+}
+
+func TestAccSystemPermissionTokenBasic(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: systemPermissionTokenBasic,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_system_permission_token.test", "name", "system-permission-token-test"),
+				resource.TestCheckTypeSetElemAttr("humio_system_permission_token.test", "permissions.*", "ReadHealthCheck"),
+				resource.TestCheckResourceAttrSet("humio_system_permission_token.test", "token"),
+			),
+		},
+	}, testAccCheckPermissionTokenDestroy)
+}
+
+func testAccCheckPermissionTokenDestroy(s *terraform.State) error {
+	conn := testAccProviders["humio"].Meta().(*humio.Client)
+
+	tokenTypes := map[string]string{
+		"humio_organization_permission_token": humio.PermissionTokenTypeOrganization,
+		"humio_view_permission_token":         humio.PermissionTokenTypeView,
+		"humio_system_permission_token":       humio.PermissionTokenTypeSystem,
+	}
+	for _, rs := range s.RootModule().Resources {
+		tokenType, ok := tokenTypes[rs.Type]
+		if !ok {
+			continue
+		}
+		resp, err := conn.PermissionTokens().Get(tokenType, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("permission token still exists for id %s: %#+v", rs.Primary.ID, *resp)
+		}
+	}
+	return nil
+}
+
+const permissionTokenEmpty = `
+resource "humio_organization_permission_token" "test" {}
+`
+
+const viewPermissionTokenEmpty = `
+resource "humio_view_permission_token" "test" {}
+`
+
+const permissionTokenInvalidSettings = `
+resource "humio_system_permission_token" "test" {
+    name        = "system-permission-token-test"
+    permissions = ["ReadHealthCheck"]
+    expire_at   = "tomorrow"
+}
+`
+
+const permissionTokenInvalidPermissions = `
+resource "humio_system_permission_token" "test" {
+    name        = "system-permission-token-test"
+    permissions = ["ReadHealthCheck", "ReadAccess"]
+}
+`
+
+const organizationPermissionTokenBasic = `
+resource "humio_organization_permission_token" "test" {
+    name        = "organization-permission-token-test"
+    permissions = ["ViewUsage"]
+}
+`
+
+const organizationPermissionTokenFull = `
+resource "humio_organization_permission_token" "test" {
+    name        = "organization-permission-token-test"
+    permissions = ["ViewUsage", "CreateRepository"]
+}
+`
+
+const viewPermissionTokenFull = `
+resource "humio_view_permission_token" "test" {
+    name        = "view-permission-token-test"
+    views       = ["sandbox"]
+    permissions = ["ReadAccess"]
+    expire_at   = "2099-01-01T01:00:00+01:00"
+}
+`
+
+const systemPermissionTokenBasic = `
+resource "humio_system_permission_token" "test" {
+    name        = "system-permission-token-test"
+    permissions = ["ReadHealthCheck"]
+}
+`
+
+var wantViewPermissionToken = humio.PermissionToken{
+	ID:          "",
+	Name:        "test-view-permission-token",
+	Type:        humio.PermissionTokenTypeView,
+	Permissions: []string{"ChangeDashboards", "ReadAccess"},
+	Views:       []string{"sandbox", "security"},
+	ExpireAt:    4070908800000,
+	IPFilterID:  "abcdef",
+}
+
+func TestEncodeDecodeViewPermissionTokenResource(t *testing.T) {
+	res := resourceViewPermissionToken()
+	data := res.TestResourceData()
+	resourceDataFromPermissionToken(&wantViewPermissionToken, data)
+	got, err := permissionTokenFromResourceData(data, humio.PermissionTokenTypeView)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantViewPermissionToken, got) {
+		t.Error(cmp.Diff(wantViewPermissionToken, got))
+	}
+}
+
+var wantSystemPermissionToken = humio.PermissionToken{
+	ID:          "",
+	Name:        "test-system-permission-token",
+	Type:        humio.PermissionTokenTypeSystem,
+	Permissions: []string{"ReadHealthCheck"},
+}
+
+func TestEncodeDecodeSystemPermissionTokenResource(t *testing.T) {
+	res := resourceSystemPermissionToken()
+	data := res.TestResourceData()
+	resourceDataFromPermissionToken(&wantSystemPermissionToken, data)
+	got, err := permissionTokenFromResourceData(data, humio.PermissionTokenTypeSystem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantSystemPermissionToken, got) {
+		t.Error(cmp.Diff(wantSystemPermissionToken, got))
+	}
+}
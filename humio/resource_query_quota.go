@@ -0,0 +1,185 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+// queryQuotaDefaultID takes the place of the username in the ID of the default query quota, which applies to users
+// without a quota of their own.
+const queryQuotaDefaultID = "default"
+
+// queryQuotaID returns the ID of the limits within a single interval of the quota of a user, or the default quota.
+func queryQuotaID(username, interval string) string {
+	if username == "" {
+		username = queryQuotaDefaultID
+	}
+	return fmt.Sprintf("%s+%s", username, interval)
+}
+
+func resourceQueryQuota() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceQueryQuotaCreate,
+		ReadContext:   resourceQueryQuotaRead,
+		UpdateContext: resourceQueryQuotaUpdate,
+		DeleteContext: resourceQueryQuotaDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Leaving out the username manages the default quota.
+			"username": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			// Each interval of a quota is managed by its own resource, leaving the limits of other intervals alone.
+			"interval": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  humio.QueryQuotaIntervalPerHour,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					humio.QueryQuotaIntervalPerDay,
+					humio.QueryQuotaIntervalPerHour,
+					humio.QueryQuotaIntervalPerTenMinutes,
+					humio.QueryQuotaIntervalPerMinute,
+				}, false)),
+			},
+			// A cost of 0 means unlimited.
+			"max_query_cost": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
+			"max_live_query_cost": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
+		},
+	}
+}
+
+func resourceQueryQuotaCreate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	queryQuota, err := queryQuotaFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain query quota from resource data: %s", err)
+	}
+
+	_, err = client.(*humio.Client).QueryQuotas().Set(
+		&queryQuota,
+	)
+	if err != nil {
+		return diag.Errorf("could not set query quota: %s", err)
+	}
+	d.SetId(queryQuotaID(d.Get("username").(string), d.Get("interval").(string)))
+
+	return resourceQueryQuotaRead(ctx, d, client)
+}
+
+func resourceQueryQuotaRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	// If we don't have an interval when importing, we parse the username and interval from the ID.
+	if _, ok := d.GetOk("interval"); !ok {
+		parts := parseRepositoryAndID(d.Id())
+		if parts[0] == "" || parts[1] == "" {
+			return diag.Errorf("error importing humio_query_quota. Please make sure the ID is in the form USERNAME+INTERVAL or default+INTERVAL (i.e. default+PerHour)")
+		}
+		if parts[0] != queryQuotaDefaultID {
+			err := d.Set("username", parts[0])
+			if err != nil {
+				return diag.Errorf("error setting username for resource %s: %s", d.Id(), err)
+			}
+		}
+		err := d.Set("interval", parts[1])
+		if err != nil {
+			return diag.Errorf("error setting interval for resource %s: %s", d.Id(), err)
+		}
+	}
+	username := d.Get("username").(string)
+	interval := d.Get("interval").(string)
+
+	queryQuota, err := client.(*humio.Client).QueryQuotas().Get(username)
+	if err != nil {
+		return diag.Errorf("could not get query quota: %s", err)
+	}
+	// A user quota that falls back to the default, or a quota without this interval, was removed outside Terraform.
+	if _, ok := queryQuota.Limits[interval]; !ok || (username != "" && queryQuota.IsDefault) {
+		d.SetId("")
+		return nil
+	}
+	d.SetId(queryQuotaID(username, interval))
+	return resourceDataFromQueryQuota(queryQuota, d)
+}
+
+func resourceDataFromQueryQuota(a *humio.QueryQuota, d *schema.ResourceData) diag.Diagnostics {
+	limits := a.Limits[d.Get("interval").(string)]
+	err := d.Set("max_query_cost", limits.MaxQueryCost)
+	if err != nil {
+		return diag.Errorf("error setting max_query_cost for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("max_live_query_cost", limits.MaxLiveQueryCost)
+	if err != nil {
+		return diag.Errorf("error setting max_live_query_cost for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceQueryQuotaUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	queryQuota, err := queryQuotaFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain query quota from resource data: %s", err)
+	}
+
+	_, err = client.(*humio.Client).QueryQuotas().Set(
+		&queryQuota,
+	)
+	if err != nil {
+		return diag.Errorf("could not update query quota: %s", err)
+	}
+	return resourceQueryQuotaRead(ctx, d, client)
+}
+
+func resourceQueryQuotaDelete(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	err := client.(*humio.Client).QueryQuotas().Remove(
+		d.Get("username").(string),
+		d.Get("interval").(string),
+	)
+	if err != nil {
+		return diag.Errorf("could not remove query quota: %s", err)
+	}
+	return nil
+}
+
+func queryQuotaFromResourceData(d *schema.ResourceData) (humio.QueryQuota, error) {
+	return humio.QueryQuota{
+		Username: d.Get("username").(string),
+		Limits: map[string]humio.QueryQuotaLimits{
+			d.Get("interval").(string): {
+				MaxQueryCost:     d.Get("max_query_cost").(int),
+				MaxLiveQueryCost: d.Get("max_live_query_cost").(int),
+			},
+		},
+	}, nil
+}
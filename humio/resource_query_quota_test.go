@@ -0,0 +1,202 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccQueryQuotaInvalidSettings(t *testing.T) {
+	config := queryQuotaInvalidSettings
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`expected interval to be one of \["PerDay" "PerHour" "PerTenMinutes" "PerMinute"\], got PerWeek`)},
+		{Config: config, ExpectError: regexp.MustCompile(`expected max_query_cost to be at least \(0\), got -1`)},
+	}, nil)
+}
+
+func TestAccQueryQuotaUserBasicToFull(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: queryQuotaUserBasic,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_query_quota.test", "id", "admin+PerHour"),
+				resource.TestCheckResourceAttr("humio_query_quota.test", "username", "admin"),
+				resource.TestCheckResourceAttr("humio_query_quota.test", "interval", "PerHour"),
+				resource.TestCheckResourceAttr("humio_query_quota.test", "max_query_cost", "1000000"),
+				resource.TestCheckResourceAttr("humio_query_quota.test", "max_live_query_cost", "0"),
+			),
+		},
+		{
+			Config: queryQuotaUserFull,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_query_quota.test", "interval", "PerDay"),
+				resource.TestCheckResourceAttr("humio_query_quota.test", "max_query_cost", "5000000"),
+				resource.TestCheckResourceAttr("humio_query_quota.test", "max_live_query_cost", "2000000"),
+			),
+		},
+		{
+			ResourceName:      "humio_query_quota.test",
+			ImportState:       true,
+			ImportStateVerify: true,
+		},
+	}, testAccCheckQueryQuotaDestroy)
+}
+
+func TestAccQueryQuotaDefault(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: queryQuotaDefault,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_query_quota.test", "id", "default+PerTenMinutes"),
+				resource.TestCheckResourceAttr("humio_query_quota.test", "username", ""),
+				resource.TestCheckResourceAttr("humio_query_quota.test", "interval", "PerTenMinutes"),
+				resource.TestCheckResourceAttr("humio_query_quota.test", "max_query_cost", "100000"),
+			),
+		},
+	}, testAccCheckQueryQuotaDestroy)
+}
+
+func TestAccQueryQuotaUserIntervals(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: queryQuotaUserIntervals,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_query_quota.hourly", "id", "admin+PerHour"),
+				resource.TestCheckResourceAttr("humio_query_quota.daily", "id", "admin+PerDay"),
+				testAccCheckQueryQuotaLimits("admin", map[string]humio.QueryQuotaLimits{
+					humio.QueryQuotaIntervalPerHour: {MaxQueryCost: 1000000},
+					humio.QueryQuotaIntervalPerDay:  {MaxQueryCost: 5000000},
+				}),
+			),
+		},
+		{
+			Config: queryQuotaUserBasic,
+			Check: resource.ComposeTestCheckFunc(
+				testAccCheckQueryQuotaLimits("admin", map[string]humio.QueryQuotaLimits{
+					humio.QueryQuotaIntervalPerHour: {MaxQueryCost: 1000000},
+				}),
+			),
+		},
+	}, testAccCheckQueryQuotaDestroy)
+}
+
+func testAccCheckQueryQuotaLimits(username string, want map[string]humio.QueryQuotaLimits) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProviders["humio"].Meta().(*humio.Client)
+		resp, err := conn.QueryQuotas().Get(username)
+		if err != nil {
+			return err
+		}
+		if !cmp.Equal(want, resp.Limits) {
+			return fmt.Errorf("unexpected query quota limits: %s", cmp.Diff(want, resp.Limits))
+		}
+		return nil
+	}
+}
+
+func testAccCheckQueryQuotaDestroy(s *terraform.State) error {
+	conn := testAccProviders["humio"].Meta().(*humio.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "humio_query_quota" {
+			continue
+		}
+		username := rs.Primary.Attributes["username"]
+		resp, err := conn.QueryQuotas().Get(username)
+		if err != nil {
+			return err
+		}
+		if _, ok := resp.Limits[rs.Primary.Attributes["interval"]]; ok && (username == "" || !resp.IsDefault) {
+			return fmt.Errorf("query quota still set for id %s: %#+v", rs.Primary.ID, *resp)
+		}
+	}
+	return nil
+}
+
+const queryQuotaInvalidSettings = `
+resource "humio_query_quota" "test" {
+    interval       = "PerWeek"
+    max_query_cost = -1
+}
+`
+
+const queryQuotaUserBasic = `
+resource "humio_query_quota" "test" {
+    username       = "admin"
+    max_query_cost = 1000000
+}
+`
+
+const queryQuotaUserFull = `
+resource "humio_query_quota" "test" {
+    username            = "admin"
+    interval            = "PerDay"
+    max_query_cost      = 5000000
+    max_live_query_cost = 2000000
+}
+`
+
+const queryQuotaUserIntervals = `
+resource "humio_query_quota" "hourly" {
+    username       = "admin"
+    max_query_cost = 1000000
+}
+
+resource "humio_query_quota" "daily" {
+    username       = "admin"
+    interval       = "PerDay"
+    max_query_cost = 5000000
+}
+`
+
+const queryQuotaDefault = `
+resource "humio_query_quota" "test" {
+    interval       = "PerTenMinutes"
+    max_query_cost = 100000
+}
+`
+
+var wantQueryQuota = humio.QueryQuota{
+	Username: "test-user",
+	Limits: map[string]humio.QueryQuotaLimits{
+		humio.QueryQuotaIntervalPerMinute: {
+			MaxQueryCost:     1000,
+			MaxLiveQueryCost: 500,
+		},
+	},
+}
+
+func TestEncodeDecodeQueryQuotaResource(t *testing.T) {
+	res := resourceQueryQuota()
+	data := res.TestResourceData()
+	data.Set("username", wantQueryQuota.Username)
+	data.Set("interval", humio.QueryQuotaIntervalPerMinute)
+	resourceDataFromQueryQuota(&wantQueryQuota, data)
+	got, err := queryQuotaFromResourceData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantQueryQuota, got) {
+		t.Error(cmp.Diff(wantQueryQuota, got))
+	}
+}
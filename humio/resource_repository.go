@@ -2,6 +2,7 @@ package humio
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -16,8 +17,9 @@ func resourceRepository() *schema.Resource {
 		ReadContext:   resourceRepositoryRead,
 		UpdateContext: resourceRepositoryUpdate,
 		DeleteContext: resourceRepositoryDelete,
+		CustomizeDiff: resourceRepositoryCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceRepositoryImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -31,16 +33,39 @@ func resourceRepository() *schema.Resource {
 				Optional: true,
 				Default:  "",
 			},
+			// Repositories hold data that cannot be recovered once deleted, so removing one, including replacing it due to a
+			// changed name, requires deletion protection to be turned off first.
+			"deletion_protection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"delete_reason": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Deleted by Terraform",
+			},
 			"retention": {
 				Type:     schema.TypeSet,
 				Optional: true,
+				Computed: true,
 				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"time_in_days": {
 							Type:             schema.TypeFloat,
 							Optional:         true,
-							ValidateDiagFunc: validation.ToDiagFunc(validation.FloatBetween(1, 365)),
+							ValidateDiagFunc: validation.ToDiagFunc(validation.FloatAtLeast(0)),
+						},
+						"ingest_size_in_gb": {
+							Type:             schema.TypeFloat,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.FloatAtLeast(0)),
+						},
+						"storage_size_in_gb": {
+							Type:             schema.TypeFloat,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.FloatAtLeast(0)),
 						},
 					},
 				},
@@ -49,6 +74,44 @@ func resourceRepository() *schema.Resource {
 	}
 }
 
+// resourceRepositoryCustomizeDiff checks the time-based retention against the longest retention the cluster allows,
+// so a too long retention fails at plan time rather than halfway through an apply.
+func resourceRepositoryCustomizeDiff(_ context.Context, d *schema.ResourceDiff, client interface{}) error {
+	if !d.HasChange("retention") || !d.NewValueKnown("retention") {
+		return nil
+	}
+	retention := d.Get("retention").(*schema.Set).List()
+	if len(retention) == 0 || retention[0] == nil {
+		return nil
+	}
+	retentionDays := retention[0].(tfMap)["time_in_days"].(float64)
+	if retentionDays == 0 {
+		return nil
+	}
+
+	maxRetentionDays, err := client.(*humio.Client).Repositories().MaxRetentionDays()
+	if err != nil {
+		// Not every cluster exposes its retention limit; leave the check to the server in that case.
+		return nil
+	}
+	if maxRetentionDays > 0 && retentionDays > maxRetentionDays {
+		return fmt.Errorf("retention time_in_days is %v, but the cluster allows at most %v days", retentionDays, maxRetentionDays)
+	}
+	return nil
+}
+
+// resourceRepositoryImport sets the Terraform-only attributes to their defaults, as they cannot be read from the
+// repository, and would otherwise show up as a diff after the import.
+func resourceRepositoryImport(ctx context.Context, d *schema.ResourceData, client interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("deletion_protection", true); err != nil {
+		return nil, err
+	}
+	if err := d.Set("delete_reason", "Deleted by Terraform"); err != nil {
+		return nil, err
+	}
+	return schema.ImportStatePassthroughContext(ctx, d, client)
+}
+
 func resourceRepositoryCreate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
 	repository, err := repositoryFromResourceData(d)
 	if err != nil {
@@ -70,12 +133,14 @@ func resourceRepositoryCreate(ctx context.Context, d *schema.ResourceData, clien
 		return diag.Errorf("could not set description for repository: %s", err)
 	}
 
-	err = client.(*humio.Client).Repositories().UpdateTimeBasedRetention(
+	err = client.(*humio.Client).Repositories().UpdateRetention(
 		repository.Name,
 		repository.RetentionDays,
+		repository.IngestSizeGB,
+		repository.StorageSizeGB,
 	)
 	if err != nil {
-		return diag.Errorf("could not set time based retention for repository: %s", err)
+		return diag.Errorf("could not set retention for repository: %s", err)
 	}
 
 	d.SetId(repository.Name)
@@ -86,7 +151,7 @@ func resourceRepositoryCreate(ctx context.Context, d *schema.ResourceData, clien
 func resourceRepositoryRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
 	repo, err := client.(*humio.Client).Repositories().Get(d.Id())
 	if err != nil {
-		diag.Errorf("could not get repository: %s", err)
+		return diag.Errorf("could not get repository: %s", err)
 	}
 	return resourceDataFromRepository(&repo, d)
 }
@@ -100,12 +165,10 @@ func resourceDataFromRepository(a *humio.Repository, d *schema.ResourceData) dia
 	if err != nil {
 		return diag.Errorf("error setting description for resource %s: %s", d.Id(), err)
 	}
-	// Only set retention in state if the config has a retention block
-	// This prevents drift when the API returns a default retention value
-	if _, ok := d.GetOk("retention"); ok {
-		if err := d.Set("retention", retentionFromRepository(a)); err != nil {
-			return diag.Errorf("error setting retention settings for resource %s: %s", d.Id(), err)
-		}
+	// Retention is always read back so changes made outside Terraform show up as drift. As the block is computed,
+	// leaving it out of the config keeps whatever retention the repository has.
+	if err := d.Set("retention", retentionFromRepository(a)); err != nil {
+		return diag.Errorf("error setting retention settings for resource %s: %s", d.Id(), err)
 	}
 	return nil
 }
@@ -113,6 +176,8 @@ func resourceDataFromRepository(a *humio.Repository, d *schema.ResourceData) dia
 func retentionFromRepository(a *humio.Repository) []tfMap {
 	s := tfMap{}
 	s["time_in_days"] = a.RetentionDays
+	s["ingest_size_in_gb"] = a.IngestSizeGB
+	s["storage_size_in_gb"] = a.StorageSizeGB
 	return []tfMap{s}
 }
 
@@ -122,19 +187,25 @@ func resourceRepositoryUpdate(ctx context.Context, d *schema.ResourceData, clien
 		return diag.Errorf("could not obtain repository from resource data: %s", err)
 	}
 
-	err = client.(*humio.Client).Repositories().UpdateDescription(
-		repository.Name,
-		repository.Description,
-	)
-	if err != nil {
-		return diag.Errorf("could not update description for repository: %s", err)
+	if d.HasChange("description") {
+		err = client.(*humio.Client).Repositories().UpdateDescription(
+			repository.Name,
+			repository.Description,
+		)
+		if err != nil {
+			return diag.Errorf("could not update description for repository: %s", err)
+		}
 	}
-	err = client.(*humio.Client).Repositories().UpdateTimeBasedRetention(
-		repository.Name,
-		repository.RetentionDays,
-	)
-	if err != nil {
-		return diag.Errorf("could not update time based retention for repository: %s", err)
+	if d.HasChange("retention") {
+		err = client.(*humio.Client).Repositories().UpdateRetention(
+			repository.Name,
+			repository.RetentionDays,
+			repository.IngestSizeGB,
+			repository.StorageSizeGB,
+		)
+		if err != nil {
+			return diag.Errorf("could not update retention for repository: %s", err)
+		}
 	}
 
 	return resourceRepositoryRead(ctx, d, client)
@@ -146,10 +217,18 @@ func resourceRepositoryDelete(_ context.Context, d *schema.ResourceData, client
 		return diag.Errorf("could not obtain repository from resource data: %s", err)
 	}
 
-	deleteReason := "Deleted by Terraform"
+	if d.Get("deletion_protection").(bool) {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Repository is protected from deletion",
+			Detail: fmt.Sprintf("refusing to delete repository %q and all of its data as deletion_protection is enabled. "+
+				"Set deletion_protection = false and apply before deleting or replacing the repository.", repository.Name),
+		}}
+	}
+
 	err = client.(*humio.Client).Repositories().Delete(
 		repository.Name,
-		deleteReason,
+		d.Get("delete_reason").(string),
 	)
 	if err != nil {
 		return diag.Errorf("could not delete repository: %s", err)
@@ -158,14 +237,19 @@ func resourceRepositoryDelete(_ context.Context, d *schema.ResourceData, client
 }
 
 func repositoryFromResourceData(d *schema.ResourceData) (humio.Repository, error) {
-	var retentionDays float64
+	var retentionDays, ingestSizeGB, storageSizeGB float64
 	if rawRetention, ok := d.GetOk("retention"); ok {
-		retentionDays = rawRetention.(*schema.Set).List()[0].(tfMap)["time_in_days"].(float64)
+		retention := rawRetention.(*schema.Set).List()[0].(tfMap)
+		retentionDays = retention["time_in_days"].(float64)
+		ingestSizeGB = retention["ingest_size_in_gb"].(float64)
+		storageSizeGB = retention["storage_size_in_gb"].(float64)
 	}
 
 	return humio.Repository{
 		Name:          d.Get("name").(string),
 		Description:   d.Get("description").(string),
 		RetentionDays: retentionDays,
+		IngestSizeGB:  ingestSizeGB,
+		StorageSizeGB: storageSizeGB,
 	}, nil
 }
@@ -0,0 +1,182 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func resourceRepositoryS3Archiving() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRepositoryS3ArchivingCreate,
+		ReadContext:   resourceRepositoryS3ArchivingRead,
+		UpdateContext: resourceRepositoryS3ArchivingUpdate,
+		DeleteContext: resourceRepositoryS3ArchivingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"format": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  humio.S3ArchivingFormatNDJSON,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					humio.S3ArchivingFormatNDJSON,
+					humio.S3ArchivingFormatRaw,
+				}, false)),
+			},
+			"start_from": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+			},
+			// Required rather than defaulted, so archiving is never disabled without it being spelled out in the
+			// configuration.
+			"enabled": {
+				Type:     schema.TypeBool,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceRepositoryS3ArchivingCreate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	config, err := s3ArchivingFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain S3 archiving configuration from resource data: %s", err)
+	}
+
+	err = client.(*humio.Client).Repositories().ConfigureS3Archiving(
+		d.Get("repository").(string),
+		&config,
+	)
+	if err != nil {
+		return diag.Errorf("could not configure S3 archiving: %s", err)
+	}
+	d.SetId(d.Get("repository").(string))
+
+	return resourceRepositoryS3ArchivingRead(ctx, d, client)
+}
+
+func resourceRepositoryS3ArchivingRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	// If we don't have a repository when importing, we use the ID.
+	if _, ok := d.GetOk("repository"); !ok {
+		err := d.Set("repository", d.Id())
+		if err != nil {
+			return diag.Errorf("error setting repository for resource %s: %s", d.Id(), err)
+		}
+	}
+
+	config, err := client.(*humio.Client).Repositories().GetS3Archiving(
+		d.Get("repository").(string),
+	)
+	if err != nil {
+		return diag.Errorf("could not get S3 archiving configuration: %s", err)
+	}
+	return resourceDataFromS3Archiving(config, d)
+}
+
+func resourceDataFromS3Archiving(a *humio.S3ArchivingConfiguration, d *schema.ResourceData) diag.Diagnostics {
+	err := d.Set("bucket", a.Bucket)
+	if err != nil {
+		return diag.Errorf("error setting bucket for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("region", a.Region)
+	if err != nil {
+		return diag.Errorf("error setting region for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("format", a.Format)
+	if err != nil {
+		return diag.Errorf("error setting format for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("start_from", a.StartFrom)
+	if err != nil {
+		return diag.Errorf("error setting start_from for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("enabled", a.Enabled)
+	if err != nil {
+		return diag.Errorf("error setting enabled for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceRepositoryS3ArchivingUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	config, err := s3ArchivingFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain S3 archiving configuration from resource data: %s", err)
+	}
+
+	err = client.(*humio.Client).Repositories().ConfigureS3Archiving(
+		d.Get("repository").(string),
+		&config,
+	)
+	if err != nil {
+		return diag.Errorf("could not update S3 archiving: %s", err)
+	}
+
+	return resourceRepositoryS3ArchivingRead(ctx, d, client)
+}
+
+func resourceRepositoryS3ArchivingDelete(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	// Removing the resource must not be the way archiving gets turned off; that has to be an explicit enabled = false.
+	if d.Get("enabled").(bool) {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "S3 archiving is still enabled",
+			Detail: fmt.Sprintf("refusing to remove the S3 archiving configuration of repository %q while archiving is enabled. "+
+				"Set enabled = false and apply before removing the resource.", d.Get("repository")),
+		}}
+	}
+
+	err := client.(*humio.Client).Repositories().ResetS3Archiving(
+		d.Get("repository").(string),
+	)
+	if err != nil {
+		return diag.Errorf("could not reset S3 archiving: %s", err)
+	}
+	return nil
+}
+
+func s3ArchivingFromResourceData(d *schema.ResourceData) (humio.S3ArchivingConfiguration, error) {
+	return humio.S3ArchivingConfiguration{
+		Bucket:    d.Get("bucket").(string),
+		Region:    d.Get("region").(string),
+		Format:    d.Get("format").(string),
+		StartFrom: d.Get("start_from").(string),
+		Enabled:   d.Get("enabled").(bool),
+	}, nil
+}
@@ -0,0 +1,93 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccRepositoryS3ArchivingRequiredFields(t *testing.T) {
+	config := repositoryS3ArchivingEmpty
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "repository" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "bucket" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "region" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "enabled" is required, but no definition was found.`)},
+	}, nil)
+}
+
+func TestAccRepositoryS3ArchivingInvalidSettings(t *testing.T) {
+	config := repositoryS3ArchivingInvalidSettings
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`expected format to be one of \["NDJSON" "RAW"\], got CSV`)},
+		{Config: config, ExpectError: regexp.MustCompile(`expected "start_from" to be a valid RFC3339 date`)},
+	}, nil)
+}
+
+const repositoryS3ArchivingEmpty = `
+resource "humio_repository_s3_archiving" "test" {}
+`
+
+const repositoryS3ArchivingInvalidSettings = `
+resource "humio_repository_s3_archiving" "test" {
+    repository = "sandbox"
+    bucket     = "humio-archive"
+    region     = "eu-west-1"
+    format     = "CSV"
+    start_from = "yesterday"
+    enabled    = true
+}
+`
+
+var wantS3Archiving = humio.S3ArchivingConfiguration{
+	Bucket:    "humio-archive",
+	Region:    "eu-west-1",
+	Format:    humio.S3ArchivingFormatRaw,
+	StartFrom: "2024-01-01T00:00:00Z",
+	Enabled:   true,
+}
+
+func TestEncodeDecodeRepositoryS3ArchivingResource(t *testing.T) {
+	res := resourceRepositoryS3Archiving()
+	data := res.TestResourceData()
+	resourceDataFromS3Archiving(&wantS3Archiving, data)
+	got, err := s3ArchivingFromResourceData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantS3Archiving, got) {
+		t.Error(cmp.Diff(wantS3Archiving, got))
+	}
+}
+
+func TestRepositoryS3ArchivingDeleteRequiresDisabled(t *testing.T) {
+	res := resourceRepositoryS3Archiving()
+	data := res.TestResourceData()
+	data.SetId("sandbox")
+	_ = data.Set("repository", "sandbox")
+	_ = data.Set("enabled", true)
+
+	diags := resourceRepositoryS3ArchivingDelete(context.Background(), data, nil)
+	if !diags.HasError() || diags[0].Summary != "S3 archiving is still enabled" {
+		t.Errorf("expected delete of enabled archiving to be refused, got %#v", diags)
+	}
+}
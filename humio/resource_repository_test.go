@@ -146,6 +146,30 @@ func TestAccRepositoryFull(t *testing.T) {
 	}, testAccCheckRepositoryDestroy)
 }
 
+func TestAccRepositoryDeletionProtection(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: repositoryProtected,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_repository.test", "name", "repository-test"),
+				resource.TestCheckResourceAttr("humio_repository.test", "deletion_protection", "true"),
+				resource.TestCheckResourceAttr("humio_repository.test", "delete_reason", "Deleted by Terraform"),
+			),
+		},
+		{
+			Config:      repositoryProtectedRenamed,
+			ExpectError: regexp.MustCompile(`Repository is protected from deletion`),
+		},
+		{
+			Config: repositoryBasic,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_repository.test", "name", "repository-test"),
+				resource.TestCheckResourceAttr("humio_repository.test", "deletion_protection", "false"),
+			),
+		},
+	}, testAccCheckRepositoryDestroy)
+}
+
 func testAccCheckRepositoryDestroy(s *terraform.State) error {
 	conn := testAccProviders["humio"].Meta().(*humio.Client)
 
@@ -188,16 +212,33 @@ resource "humio_repository" "test" {
 `
 
 const repositoryBasic = `
+resource "humio_repository" "test" {
+    name                = "repository-test"
+    deletion_protection = false
+    retention {}
+}
+`
+
+const repositoryProtected = `
 resource "humio_repository" "test" {
     name = "repository-test"
     retention {}
 }
 `
 
+const repositoryProtectedRenamed = `
+resource "humio_repository" "test" {
+    name = "repository-test-renamed"
+    retention {}
+}
+`
+
 const repositoryFull = `
 resource "humio_repository" "test" {
-    name        = "repository-test"
-    description = "some description"
+    name                = "repository-test"
+    description         = "some description"
+    deletion_protection = false
+    delete_reason       = "Acceptance test"
     retention {
         storage_size_in_gb = 5
         ingest_size_in_gb  = 10
@@ -210,6 +251,8 @@ var wantRepository = humio.Repository{
 	Name:          "test-repository",
 	Description:   "important",
 	RetentionDays: 30,
+	IngestSizeGB:  10,
+	StorageSizeGB: 5,
 }
 
 func TestEncodeDecodeRepositoryResource(t *testing.T) {
@@ -0,0 +1,240 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func resourceSAMLIdentityProvider() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSAMLIdentityProviderCreate,
+		ReadContext:   resourceSAMLIdentityProviderRead,
+		UpdateContext: resourceSAMLIdentityProviderUpdate,
+		DeleteContext: resourceSAMLIdentityProviderDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			},
+			// Users logging in with an email address in one of the domains are sent to this identity provider.
+			"domains": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"sign_on_url": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPS),
+			},
+			"idp_entity_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"idp_certificate": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Sensitive:        true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateCertificatePEM),
+			},
+			// A second certificate is accepted while rotating the certificate of the identity provider.
+			"alternative_idp_certificate": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "",
+				Sensitive:        true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateCertificatePEM),
+			},
+			"user_attribute": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"group_membership_attribute": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"lazy_create_users": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"default_idp": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"debug": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+// validateCertificatePEM checks that the value is a PEM-encoded certificate. Empty values are left to the Required
+// check of the attribute.
+func validateCertificatePEM(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if v == "" {
+		return nil, nil
+	}
+	block, _ := pem.Decode([]byte(v))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, []error{fmt.Errorf("expected %s to be a PEM-encoded certificate", k)}
+	}
+	return nil, nil
+}
+
+func resourceSAMLIdentityProviderCreate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	provider, err := samlIdentityProviderFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain SAML identity provider from resource data: %s", err)
+	}
+
+	p, err := client.(*humio.Client).IdentityProviders().AddSAML(
+		&provider,
+	)
+	if err != nil {
+		return diag.Errorf("could not create SAML identity provider: %s", err)
+	}
+	d.SetId(p.ID)
+
+	return resourceSAMLIdentityProviderRead(ctx, d, client)
+}
+
+func resourceSAMLIdentityProviderRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	provider, err := client.(*humio.Client).IdentityProviders().GetSAML(
+		d.Id(),
+	)
+	if err != nil {
+		return diag.Errorf("could not get SAML identity provider: %s", err)
+	}
+	return resourceDataFromSAMLIdentityProvider(provider, d)
+}
+
+func resourceDataFromSAMLIdentityProvider(a *humio.SAMLIdentityProvider, d *schema.ResourceData) diag.Diagnostics {
+	err := d.Set("name", a.Name)
+	if err != nil {
+		return diag.Errorf("error setting name for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("domains", a.Domains)
+	if err != nil {
+		return diag.Errorf("error setting domains for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("sign_on_url", a.SignOnURL)
+	if err != nil {
+		return diag.Errorf("error setting sign_on_url for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("idp_entity_id", a.IdPEntityID)
+	if err != nil {
+		return diag.Errorf("error setting idp_entity_id for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("idp_certificate", a.IdPCertificate)
+	if err != nil {
+		return diag.Errorf("error setting idp_certificate for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("alternative_idp_certificate", a.AlternativeIdPCertificate)
+	if err != nil {
+		return diag.Errorf("error setting alternative_idp_certificate for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("user_attribute", a.UserAttribute)
+	if err != nil {
+		return diag.Errorf("error setting user_attribute for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("group_membership_attribute", a.GroupMembershipAttribute)
+	if err != nil {
+		return diag.Errorf("error setting group_membership_attribute for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("lazy_create_users", a.LazyCreateUsers)
+	if err != nil {
+		return diag.Errorf("error setting lazy_create_users for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("default_idp", a.DefaultIdP)
+	if err != nil {
+		return diag.Errorf("error setting default_idp for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("debug", a.Debug)
+	if err != nil {
+		return diag.Errorf("error setting debug for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceSAMLIdentityProviderUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	provider, err := samlIdentityProviderFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain SAML identity provider from resource data: %s", err)
+	}
+
+	_, err = client.(*humio.Client).IdentityProviders().UpdateSAML(
+		&provider,
+	)
+	if err != nil {
+		return diag.Errorf("could not update SAML identity provider: %s", err)
+	}
+	return resourceSAMLIdentityProviderRead(ctx, d, client)
+}
+
+func resourceSAMLIdentityProviderDelete(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	err := client.(*humio.Client).IdentityProviders().Delete(
+		d.Id(),
+	)
+	if err != nil {
+		return diag.Errorf("could not delete SAML identity provider: %s", err)
+	}
+	return nil
+}
+
+func samlIdentityProviderFromResourceData(d *schema.ResourceData) (humio.SAMLIdentityProvider, error) {
+	domains := convertInterfaceListToStringSlice(d.Get("domains").(*schema.Set).List())
+	sort.Strings(domains)
+
+	return humio.SAMLIdentityProvider{
+		ID:                        d.Id(),
+		Name:                      d.Get("name").(string),
+		Domains:                   domains,
+		IdPCertificate:            d.Get("idp_certificate").(string),
+		AlternativeIdPCertificate: d.Get("alternative_idp_certificate").(string),
+		IdPEntityID:               d.Get("idp_entity_id").(string),
+		SignOnURL:                 d.Get("sign_on_url").(string),
+		UserAttribute:             d.Get("user_attribute").(string),
+		GroupMembershipAttribute:  d.Get("group_membership_attribute").(string),
+		LazyCreateUsers:           d.Get("lazy_create_users").(bool),
+		DefaultIdP:                d.Get("default_idp").(bool),
+		Debug:                     d.Get("debug").(bool),
+	}, nil
+}
@@ -0,0 +1,180 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSAMLIdentityProviderRequiredFields(t *testing.T) {
+	config := samlIdentityProviderEmpty
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "name" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "domains" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "sign_on_url" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "idp_entity_id" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "idp_certificate" is required, but no definition was found.`)},
+	}, nil)
+}
+
+func TestAccSAMLIdentityProviderInvalidSettings(t *testing.T) {
+	config := samlIdentityProviderInvalidSettings
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`expected "sign_on_url" to have a host, got not-a-url`)},
+		{Config: config, ExpectError: regexp.MustCompile(`expected idp_certificate to be a PEM-encoded certificate`)},
+	}, nil)
+}
+
+func TestAccSAMLIdentityProviderBasicToFull(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: samlIdentityProviderBasic,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_saml_identity_provider.test", "name", "saml-identity-provider-test"),
+				resource.TestCheckResourceAttr("humio_saml_identity_provider.test", "domains.#", "1"),
+				resource.TestCheckResourceAttr("humio_saml_identity_provider.test", "sign_on_url", "https://idp.example.com/sso"),
+				resource.TestCheckResourceAttr("humio_saml_identity_provider.test", "lazy_create_users", "true"),
+				resource.TestCheckResourceAttr("humio_saml_identity_provider.test", "debug", "false"),
+			),
+		},
+		{
+			Config: samlIdentityProviderFull,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_saml_identity_provider.test", "domains.#", "2"),
+				resource.TestCheckResourceAttr("humio_saml_identity_provider.test", "user_attribute", "email"),
+				resource.TestCheckResourceAttr("humio_saml_identity_provider.test", "group_membership_attribute", "groups"),
+				resource.TestCheckResourceAttr("humio_saml_identity_provider.test", "lazy_create_users", "false"),
+				resource.TestCheckResourceAttr("humio_saml_identity_provider.test", "debug", "true"),
+			),
+		},
+		{
+			ResourceName:      "humio_saml_identity_provider.test",
+			ImportState:       true,
+			ImportStateVerify: true,
+		},
+	}, testAccCheckIdentityProviderDestroy)
+}
+
+func testAccCheckIdentityProviderDestroy(s *terraform.State) error {
+	conn := testAccProviders["humio"].Meta().(*humio.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		switch rs.Type {
+		case "humio_saml_identity_provider":
+			resp, err := conn.IdentityProviders().GetSAML(rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("SAML identity provider still exists for id %s: %#+v", rs.Primary.ID, *resp)
+			}
+		case "humio_oidc_identity_provider":
+			resp, err := conn.IdentityProviders().GetOIDC(rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("OIDC identity provider still exists for id %s: %#+v", rs.Primary.ID, *resp)
+			}
+		}
+	}
+	return nil
+}
+
+const testCertificate = `-----BEGIN CERTIFICATE-----
+MIICEDCCAXmgAwIBAgIUChUh76vvyKAJ18Guim4f47KiWRMwDQYJKoZIhvcNAQEL
+BQAwGjEYMBYGA1UEAwwPaWRwLmV4YW1wbGUuY29tMB4XDTI2MTAxODE2MjMyNVoX
+DTM2MTAxNTE2MjMyNVowGjEYMBYGA1UEAwwPaWRwLmV4YW1wbGUuY29tMIGfMA0G
+CSqGSIb3DQEBAQUAA4GNADCBiQKBgQCeWIBqVPOmTLlDNK3as9Z9EkDCJ9oRtxGd
+xrQ+2aHRBNTXRpVJRhseX2vCD2D8a8IxS9CZ2kx+S0i7dl+7UcBp17f5WRvJlgU3
+jAnaWhqrRgmD9lJZ9WtOkJadlNW5Y6TGNCArkj8RcGGd78fUXVbk52Cvvz0q1hic
+VLHKomKdLQIDAQABo1MwUTAdBgNVHQ4EFgQU8OrY/S53l7jDYVQoGHlwxFmSZvkw
+HwYDVR0jBBgwFoAU8OrY/S53l7jDYVQoGHlwxFmSZvkwDwYDVR0TAQH/BAUwAwEB
+/zANBgkqhkiG9w0BAQsFAAOBgQBTcBfeRVuTGRSE+X/00EvNvItbFckVuKgwJvDb
+lZNS17dDvtP6MAa/C19E4oRqxe26CYgxjgAB1ogFTiigBeLxcSmAEHBpi8j918EV
+mBc3KSIZoBTX6lw3Lt5KOMEtpItXUQhDI3IdJmB5Hhg8EPw2tPnEh4kcjBPQdSGo
+9KYRfQ==
+-----END CERTIFICATE-----
+`
+
+const samlIdentityProviderEmpty = `
+resource "humio_saml_identity_provider" "test" {}
+`
+
+const samlIdentityProviderInvalidSettings = `
+resource "humio_saml_identity_provider" "test" {
+    name            = "saml-identity-provider-test"
+    domains         = ["example.com"]
+    sign_on_url     = "not-a-url"
+    idp_entity_id   = "https://idp.example.com"
+    idp_certificate = "not a certificate"
+}
+`
+
+const samlIdentityProviderBasic = `
+resource "humio_saml_identity_provider" "test" {
+    name            = "saml-identity-provider-test"
+    domains         = ["example.com"]
+    sign_on_url     = "https://idp.example.com/sso"
+    idp_entity_id   = "https://idp.example.com"
+    idp_certificate = <<EOT
+` + testCertificate + `EOT
+}
+`
+
+const samlIdentityProviderFull = `
+resource "humio_saml_identity_provider" "test" {
+    name                       = "saml-identity-provider-test"
+    domains                    = ["example.com", "example.org"]
+    sign_on_url                = "https://idp.example.com/sso"
+    idp_entity_id              = "https://idp.example.com"
+    idp_certificate            = <<EOT
+` + testCertificate + `EOT
+    user_attribute             = "email"
+    group_membership_attribute = "groups"
+    lazy_create_users          = false
+    debug                      = true
+}
+`
+
+var wantSAMLIdentityProvider = humio.SAMLIdentityProvider{
+	ID:                        "",
+	Name:                      "test-saml-identity-provider",
+	Domains:                   []string{"example.com", "example.org"},
+	IdPCertificate:            testCertificate,
+	AlternativeIdPCertificate: "",
+	IdPEntityID:               "https://idp.example.com",
+	SignOnURL:                 "https://idp.example.com/sso",
+	UserAttribute:             "email",
+	GroupMembershipAttribute:  "groups",
+	LazyCreateUsers:           true,
+	DefaultIdP:                false,
+	Debug:                     true,
+}
+
+func TestEncodeDecodeSAMLIdentityProviderResource(t *testing.T) {
+	res := resourceSAMLIdentityProvider()
+	data := res.TestResourceData()
+	resourceDataFromSAMLIdentityProvider(&wantSAMLIdentityProvider, data)
+	got, err := samlIdentityProviderFromResourceData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantSAMLIdentityProvider, got) {
+		t.Error(cmp.Diff(wantSAMLIdentityProvider, got))
+	}
+}
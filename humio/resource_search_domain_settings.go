@@ -0,0 +1,197 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func resourceSearchDomainSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSearchDomainSettingsCreate,
+		ReadContext:   resourceSearchDomainSettingsRead,
+		UpdateContext: resourceSearchDomainSettingsUpdate,
+		DeleteContext: resourceSearchDomainSettingsDelete,
+		// An imported resource has no saved query of its own, so the current default query, which may belong to a
+		// user, is never changed or deleted. The next apply of a default query creates one and makes it the default.
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		// The description is left to humio_repository, so the two can be used for the same repository.
+		Schema: map[string]*schema.Schema{
+			"search_domain": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"automatic_search": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"default_query": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			// The saved query created to hold the default query. It is the only saved query the resource changes or
+			// deletes.
+			"default_query_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			// A relative time such as 15m or 7d.
+			"default_time_window": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(
+					regexp.MustCompile(`^([0-9]+(ms|s|m|h|d|w|y))?$`),
+					"must be a relative time such as 15m or 7d",
+				)),
+			},
+		},
+	}
+}
+
+func resourceSearchDomainSettingsCreate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	settings, err := searchDomainSettingsFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain search domain settings from resource data: %s", err)
+	}
+
+	updated, err := client.(*humio.Client).SearchDomains().UpdateSettings(
+		&settings,
+	)
+	if updated != nil {
+		// Keep track of a created saved query even if the update failed, so it is not orphaned.
+		d.SetId(settings.SearchDomain)
+		diags := setSearchDomainDefaultQueryID(updated, d)
+		if diags != nil {
+			return diags
+		}
+	}
+	if err != nil {
+		return diag.Errorf("could not update search domain settings: %s", err)
+	}
+
+	return resourceSearchDomainSettingsRead(ctx, d, client)
+}
+
+func resourceSearchDomainSettingsRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	settings, err := client.(*humio.Client).SearchDomains().GetSettings(
+		d.Id(),
+	)
+	if err != nil {
+		return diag.Errorf("could not get search domain settings: %s", err)
+	}
+	// A default query that is not the saved query of the resource is not ours to manage, so it is shown as no default
+	// query at all.
+	if settings.DefaultQueryID != d.Get("default_query_id").(string) {
+		settings.DefaultQueryID = d.Get("default_query_id").(string)
+		settings.DefaultQuery = ""
+		settings.DefaultTimeWindow = ""
+	}
+	return resourceDataFromSearchDomainSettings(settings, d)
+}
+
+func resourceDataFromSearchDomainSettings(a *humio.SearchDomainSettings, d *schema.ResourceData) diag.Diagnostics {
+	err := d.Set("search_domain", a.SearchDomain)
+	if err != nil {
+		return diag.Errorf("error setting search_domain for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("automatic_search", a.AutomaticSearch)
+	if err != nil {
+		return diag.Errorf("error setting automatic_search for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("default_query", a.DefaultQuery)
+	if err != nil {
+		return diag.Errorf("error setting default_query for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("default_time_window", a.DefaultTimeWindow)
+	if err != nil {
+		return diag.Errorf("error setting default_time_window for resource %s: %s", d.Id(), err)
+	}
+	return setSearchDomainDefaultQueryID(a, d)
+}
+
+func setSearchDomainDefaultQueryID(a *humio.SearchDomainSettings, d *schema.ResourceData) diag.Diagnostics {
+	err := d.Set("default_query_id", a.DefaultQueryID)
+	if err != nil {
+		return diag.Errorf("error setting default_query_id for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceSearchDomainSettingsUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	settings, err := searchDomainSettingsFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain search domain settings from resource data: %s", err)
+	}
+
+	updated, err := client.(*humio.Client).SearchDomains().UpdateSettings(
+		&settings,
+	)
+	if updated != nil {
+		diags := setSearchDomainDefaultQueryID(updated, d)
+		if diags != nil {
+			return diags
+		}
+	}
+	if err != nil {
+		return diag.Errorf("could not update search domain settings: %s", err)
+	}
+	return resourceSearchDomainSettingsRead(ctx, d, client)
+}
+
+// resourceSearchDomainSettingsDelete deletes the saved query holding the default query. Automatic search is left as
+// it is, as its value from before the resource was created is not known.
+func resourceSearchDomainSettingsDelete(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	current, err := client.(*humio.Client).SearchDomains().GetSettings(
+		d.Id(),
+	)
+	if err != nil {
+		return diag.Errorf("could not get search domain settings: %s", err)
+	}
+	_, err = client.(*humio.Client).SearchDomains().UpdateSettings(
+		&humio.SearchDomainSettings{
+			SearchDomain:    d.Id(),
+			AutomaticSearch: current.AutomaticSearch,
+			DefaultQueryID:  d.Get("default_query_id").(string),
+		},
+	)
+	if err != nil {
+		return diag.Errorf("could not remove default query of search domain: %s", err)
+	}
+	return nil
+}
+
+func searchDomainSettingsFromResourceData(d *schema.ResourceData) (humio.SearchDomainSettings, error) {
+	return humio.SearchDomainSettings{
+		SearchDomain:      d.Get("search_domain").(string),
+		AutomaticSearch:   d.Get("automatic_search").(bool),
+		DefaultQueryID:    d.Get("default_query_id").(string),
+		DefaultQuery:      d.Get("default_query").(string),
+		DefaultTimeWindow: d.Get("default_time_window").(string),
+	}, nil
+}
@@ -0,0 +1,135 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"regexp"
+	"testing"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSearchDomainSettingsRequiredFields(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{Config: searchDomainSettingsEmpty, ExpectError: regexp.MustCompile(`The argument "search_domain" is required, but no definition was found.`)},
+	}, nil)
+}
+
+func TestAccSearchDomainSettingsInvalidSettings(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{Config: searchDomainSettingsInvalidSettings, ExpectError: regexp.MustCompile(`invalid value for default_time_window \(must be a relative time such as 15m or 7d\)`)},
+	}, nil)
+}
+
+func TestAccSearchDomainSettingsBasicToFull(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: searchDomainSettingsBasic,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_search_domain_settings.test", "id", "search-domain-settings-test"),
+				resource.TestCheckResourceAttr("humio_search_domain_settings.test", "automatic_search", "false"),
+				resource.TestCheckResourceAttr("humio_search_domain_settings.test", "default_query", ""),
+				resource.TestCheckResourceAttr("humio_repository.test", "description", "Owned by humio_repository"),
+			),
+		},
+		{
+			Config: searchDomainSettingsFull,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_search_domain_settings.test", "automatic_search", "true"),
+				resource.TestCheckResourceAttr("humio_search_domain_settings.test", "default_query", "#type=accesslog | status >= 500"),
+				resource.TestCheckResourceAttr("humio_search_domain_settings.test", "default_time_window", "7d"),
+				resource.TestCheckResourceAttrSet("humio_search_domain_settings.test", "default_query_id"),
+				resource.TestCheckResourceAttr("humio_repository.test", "description", "Owned by humio_repository"),
+			),
+		},
+		{
+			ResourceName:      "humio_search_domain_settings.test",
+			ImportState:       true,
+			ImportStateVerify: true,
+			// The saved query created by the resource is not taken over on import.
+			ImportStateVerifyIgnore: []string{"default_query", "default_time_window", "default_query_id"},
+		},
+		{
+			Config: searchDomainSettingsBasic,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("humio_search_domain_settings.test", "default_query", ""),
+				resource.TestCheckResourceAttr("humio_search_domain_settings.test", "default_time_window", ""),
+				resource.TestCheckResourceAttr("humio_search_domain_settings.test", "default_query_id", ""),
+			),
+		},
+	}, testAccCheckRepositoryDestroy)
+}
+
+const searchDomainSettingsEmpty = `
+resource "humio_search_domain_settings" "test" {}
+`
+
+const searchDomainSettingsInvalidSettings = `
+resource "humio_search_domain_settings" "test" {
+    search_domain       = "search-domain-settings-test"
+    default_time_window = "a week"
+}
+`
+
+const searchDomainSettingsBasic = `
+resource "humio_repository" "test" {
+    name                = "search-domain-settings-test"
+    description         = "Owned by humio_repository"
+    deletion_protection = false
+}
+
+resource "humio_search_domain_settings" "test" {
+    search_domain    = humio_repository.test.name
+    automatic_search = false
+}
+`
+
+const searchDomainSettingsFull = `
+resource "humio_repository" "test" {
+    name                = "search-domain-settings-test"
+    description         = "Owned by humio_repository"
+    deletion_protection = false
+}
+
+resource "humio_search_domain_settings" "test" {
+    search_domain       = humio_repository.test.name
+    default_query       = "#type=accesslog | status >= 500"
+    default_time_window = "7d"
+}
+`
+
+var wantSearchDomainSettings = humio.SearchDomainSettings{
+	SearchDomain:      "test-repository",
+	AutomaticSearch:   false,
+	DefaultQueryID:    "abcdef",
+	DefaultQuery:      "#type=accesslog",
+	DefaultTimeWindow: "24h",
+}
+
+func TestEncodeDecodeSearchDomainSettingsResource(t *testing.T) {
+	res := resourceSearchDomainSettings()
+	data := res.TestResourceData()
+	resourceDataFromSearchDomainSettings(&wantSearchDomainSettings, data)
+	got, err := searchDomainSettingsFromResourceData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantSearchDomainSettings, got) {
+		t.Error(cmp.Diff(wantSearchDomainSettings, got))
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+)
+
+func resourceSharedDashboardLink() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSharedDashboardLinkCreate,
+		ReadContext:   resourceSharedDashboardLinkRead,
+		DeleteContext: resourceSharedDashboardLinkDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		// The API has no way of changing a link, so every attribute forces a new one.
+		Schema: map[string]*schema.Schema{
+			"view": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"dashboard_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"ip_filter_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"token": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceSharedDashboardLinkCreate(ctx context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	link, err := sharedDashboardLinkFromResourceData(d)
+	if err != nil {
+		return diag.Errorf("could not obtain shared dashboard link from resource data: %s", err)
+	}
+
+	_, err = client.(*humio.Client).SharedDashboardLinks().Add(
+		&link,
+	)
+	if err != nil {
+		return diag.Errorf("could not create shared dashboard link: %s", err)
+	}
+	d.SetId(fmt.Sprintf("%s+%s+%s", d.Get("view"), link.DashboardID, link.Name))
+
+	return resourceSharedDashboardLinkRead(ctx, d, client)
+}
+
+func resourceSharedDashboardLinkRead(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	parts := parseRepositoryAndID(d.Id())
+	dashboardParts := parseRepositoryAndID(parts[1])
+	//we check that we have parsed the id into the correct number of segments
+	if parts[0] == "" || dashboardParts[0] == "" || dashboardParts[1] == "" {
+		return diag.Errorf("error importing humio_shared_dashboard_link. Please make sure the ID is in the form VIEWNAME+DASHBOARDID+LINKNAME (i.e. myViewName+abcdefghijklmnopqrstuvwxyz012345+myLink")
+	}
+	// If we don't have a view or dashboard when importing, we parse them from the ID.
+	if _, ok := d.GetOk("view"); !ok {
+		err := d.Set("view", parts[0])
+		if err != nil {
+			return diag.Errorf("error setting view for resource %s: %s", d.Id(), err)
+		}
+	}
+	if _, ok := d.GetOk("dashboard_id"); !ok {
+		err := d.Set("dashboard_id", dashboardParts[0])
+		if err != nil {
+			return diag.Errorf("error setting dashboard_id for resource %s: %s", d.Id(), err)
+		}
+	}
+
+	link, err := client.(*humio.Client).SharedDashboardLinks().Get(
+		d.Get("view").(string),
+		d.Get("dashboard_id").(string),
+		dashboardParts[1],
+	)
+	if err != nil {
+		return diag.Errorf("could not get shared dashboard link: %s", err)
+	}
+	return resourceDataFromSharedDashboardLink(link, d)
+}
+
+func resourceDataFromSharedDashboardLink(a *humio.SharedDashboardLink, d *schema.ResourceData) diag.Diagnostics {
+	err := d.Set("dashboard_id", a.DashboardID)
+	if err != nil {
+		return diag.Errorf("error setting dashboard_id for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("name", a.Name)
+	if err != nil {
+		return diag.Errorf("error setting name for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("ip_filter_id", a.IPFilterID)
+	if err != nil {
+		return diag.Errorf("error setting ip_filter_id for resource %s: %s", d.Id(), err)
+	}
+	err = d.Set("token", a.Token)
+	if err != nil {
+		return diag.Errorf("error setting token for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceSharedDashboardLinkDelete(_ context.Context, d *schema.ResourceData, client interface{}) diag.Diagnostics {
+	err := client.(*humio.Client).SharedDashboardLinks().Delete(
+		d.Get("dashboard_id").(string),
+		d.Get("token").(string),
+	)
+	if err != nil {
+		return diag.Errorf("could not delete shared dashboard link: %s", err)
+	}
+	return nil
+}
+
+func sharedDashboardLinkFromResourceData(d *schema.ResourceData) (humio.SharedDashboardLink, error) {
+	return humio.SharedDashboardLink{
+		Name:        d.Get("name").(string),
+		DashboardID: d.Get("dashboard_id").(string),
+		IPFilterID:  d.Get("ip_filter_id").(string),
+		Token:       d.Get("token").(string),
+	}, nil
+}
@@ -0,0 +1,75 @@
+// Copyright © 2020 Humio Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humio
+
+import (
+	"regexp"
+	"testing"
+
+	humio "github.com/clearhaus/terraform-provider-humio/internal/api"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSharedDashboardLinkRequiredFields(t *testing.T) {
+	config := sharedDashboardLinkEmpty
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "view" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "dashboard_id" is required, but no definition was found.`)},
+		{Config: config, ExpectError: regexp.MustCompile(`The argument "name" is required, but no definition was found.`)},
+	}, nil)
+}
+
+func TestAccSharedDashboardLinkInvalidInputs(t *testing.T) {
+	config := sharedDashboardLinkInvalidInputs
+	accTestCase(t, []resource.TestStep{
+		{Config: config, ExpectError: regexp.MustCompile(`Inappropriate value for attribute "view"`)},
+		{Config: config, ExpectError: regexp.MustCompile(`Inappropriate value for attribute "ip_filter_id"`)},
+	}, nil)
+}
+
+const sharedDashboardLinkEmpty = `
+resource "humio_shared_dashboard_link" "test" {}
+`
+
+const sharedDashboardLinkInvalidInputs = `
+resource "humio_shared_dashboard_link" "test" {
+    view         = ["invalid"]
+    dashboard_id = "abcdef"
+    name         = "shared-dashboard-link-test"
+    ip_filter_id = ["invalid"]
+}
+`
+
+var wantSharedDashboardLink = humio.SharedDashboardLink{
+	Name:        "test-shared-dashboard-link",
+	DashboardID: "abcdef",
+	IPFilterID:  "ghijkl",
+	Token:       "secret",
+}
+
+func TestEncodeDecodeSharedDashboardLinkResource(t *testing.T) {
+	res := resourceSharedDashboardLink()
+	data := res.TestResourceData()
+	resourceDataFromSharedDashboardLink(&wantSharedDashboardLink, data)
+	got, err := sharedDashboardLinkFromResourceData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantSharedDashboardLink, got) {
+		t.Error(cmp.Diff(wantSharedDashboardLink, got))
+	}
+}
@@ -137,6 +137,57 @@ func (c *Client) Query(ctx context.Context, query string, variables map[string]i
 	return nil
 }
 
+// restRequest executes a request against the REST API and unmarshals the JSON result into the provided target
+func (c *Client) restRequest(ctx context.Context, method, path string, query url.Values, contentType string, body io.Reader, target interface{}) error {
+	restURL := c.config.Address.JoinPath(path)
+	if query != nil {
+		restURL.RawQuery = query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, restURL.String(), body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.config.Token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &statusCodeError{StatusCode: resp.StatusCode, Body: respBody}
+	}
+
+	if target != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, target); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// statusCodeError is returned by restRequest when the server answers with a status code outside of 2xx
+type statusCodeError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *statusCodeError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, string(e.Body))
+}
+
 // Alerts returns the Alerts API
 func (c *Client) Alerts() *Alerts {
 	return &Alerts{client: c}
@@ -147,21 +198,101 @@ func (c *Client) Actions() *Actions {
 	return &Actions{client: c}
 }
 
+// Cluster returns the Cluster API
+func (c *Client) Cluster() *ClusterInfo {
+	return &ClusterInfo{client: c}
+}
+
+// Collectors returns the Collectors API
+func (c *Client) Collectors() *Collectors {
+	return &Collectors{client: c}
+}
+
+// DynamicConfigs returns the DynamicConfigs API
+func (c *Client) DynamicConfigs() *DynamicConfigs {
+	return &DynamicConfigs{client: c}
+}
+
+// FieldAliases returns the FieldAliases API
+func (c *Client) FieldAliases() *FieldAliases {
+	return &FieldAliases{client: c}
+}
+
+// Interactions returns the Interactions API
+func (c *Client) Interactions() *Interactions {
+	return &Interactions{client: c}
+}
+
+// IPFilters returns the IPFilters API
+func (c *Client) IPFilters() *IPFilters {
+	return &IPFilters{client: c}
+}
+
+// Organization returns the Organization API
+func (c *Client) Organization() *Organization {
+	return &Organization{client: c}
+}
+
 // Parsers returns the Parsers API
 func (c *Client) Parsers() *Parsers {
 	return &Parsers{client: c}
 }
 
+// PermissionTokens returns the PermissionTokens API
+func (c *Client) PermissionTokens() *PermissionTokens {
+	return &PermissionTokens{client: c}
+}
+
+// QueryJobs returns the QueryJobs API
+func (c *Client) QueryJobs() *QueryJobs {
+	return &QueryJobs{client: c}
+}
+
+// QueryQuotas returns the QueryQuotas API
+func (c *Client) QueryQuotas() *QueryQuotas {
+	return &QueryQuotas{client: c}
+}
+
 // Repositories returns the Repositories API
 func (c *Client) Repositories() *Repositories {
 	return &Repositories{client: c}
 }
 
+// IdentityProviders returns the IdentityProviders API
+func (c *Client) IdentityProviders() *IdentityProviders {
+	return &IdentityProviders{client: c}
+}
+
 // IngestTokens returns the IngestTokens API
 func (c *Client) IngestTokens() *IngestTokens {
 	return &IngestTokens{client: c}
 }
 
+// IngestFeeds returns the IngestFeeds API
+func (c *Client) IngestFeeds() *IngestFeeds {
+	return &IngestFeeds{client: c}
+}
+
+// IngestListeners returns the IngestListeners API
+func (c *Client) IngestListeners() *IngestListeners {
+	return &IngestListeners{client: c}
+}
+
+// Packages returns the Packages API
+func (c *Client) Packages() *Packages {
+	return &Packages{client: c}
+}
+
+// SearchDomains returns the SearchDomains API
+func (c *Client) SearchDomains() *SearchDomains {
+	return &SearchDomains{client: c}
+}
+
+// SharedDashboardLinks returns the SharedDashboardLinks API
+func (c *Client) SharedDashboardLinks() *SharedDashboardLinks {
+	return &SharedDashboardLinks{client: c}
+}
+
 // Users returns the Users API
 func (c *Client) Users() *Users {
 	return &Users{client: c}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// Cluster health status constants
+const (
+	ClusterHealthStatusOK   = "OK"
+	ClusterHealthStatusWarn = "WARN"
+	ClusterHealthStatusDown = "DOWN"
+)
+
+// Cluster represents the LogScale cluster the client is connected to
+type Cluster struct {
+	ID      string
+	Version string
+	Nodes   []ClusterNode
+}
+
+// ClusterNode represents a single node of the cluster
+type ClusterNode struct {
+	ID          int
+	Name        string
+	URI         string
+	Zone        string
+	Version     string
+	Roles       []string
+	IsAvailable bool
+}
+
+// ClusterHealth represents the health of the cluster as reported by the node answering the request
+type ClusterHealth struct {
+	Status        string
+	StatusMessage string
+	Checks        []ClusterHealthCheck
+}
+
+// ClusterHealthCheck represents a single check contributing to the overall cluster health
+type ClusterHealthCheck struct {
+	Name          string
+	Status        string
+	StatusMessage string
+}
+
+// ClusterInfo provides read-only operations for inspecting the cluster
+type ClusterInfo struct {
+	client *Client
+}
+
+const getClusterQuery = `
+query GetCluster {
+  meta {
+    clusterId
+    version
+  }
+  cluster {
+    nodes {
+      id
+      name
+      uri
+      zone
+      humioVersion
+      roles
+      isAvailable
+    }
+  }
+}
+`
+
+// getClusterResponse represents the response from get cluster query
+type getClusterResponse struct {
+	Meta struct {
+		ClusterID string `json:"clusterId"`
+		Version   string `json:"version"`
+	} `json:"meta"`
+	Cluster struct {
+		Nodes []struct {
+			ID           int      `json:"id"`
+			Name         string   `json:"name"`
+			URI          string   `json:"uri"`
+			Zone         *string  `json:"zone"`
+			HumioVersion string   `json:"humioVersion"`
+			Roles        []string `json:"roles"`
+			IsAvailable  bool     `json:"isAvailable"`
+		} `json:"nodes"`
+	} `json:"cluster"`
+}
+
+// healthResponse represents the response from the health-json endpoint
+type healthResponse struct {
+	Status        string `json:"status"`
+	StatusMessage string `json:"statusMessage"`
+	Checks        []struct {
+		Name          string `json:"name"`
+		Status        string `json:"status"`
+		StatusMessage string `json:"statusMessage"`
+	} `json:"checks"`
+}
+
+// Get returns the version, ID and nodes of the cluster
+func (c *ClusterInfo) Get() (*Cluster, error) {
+	var resp getClusterResponse
+	err := c.client.Query(context.Background(), getClusterQuery, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := &Cluster{
+		ID:      resp.Meta.ClusterID,
+		Version: resp.Meta.Version,
+		Nodes:   make([]ClusterNode, len(resp.Cluster.Nodes)),
+	}
+	for i, node := range resp.Cluster.Nodes {
+		cluster.Nodes[i] = ClusterNode{
+			ID:          node.ID,
+			Name:        node.Name,
+			URI:         node.URI,
+			Zone:        stringValue(node.Zone),
+			Version:     node.HumioVersion,
+			Roles:       node.Roles,
+			IsAvailable: node.IsAvailable,
+		}
+	}
+
+	return cluster, nil
+}
+
+// Health returns the health of the cluster. The server answers with an error status code when the cluster is down,
+// but still describes its health in the body, so only failing to get or decode the health is an error.
+func (c *ClusterInfo) Health() (*ClusterHealth, error) {
+	var resp healthResponse
+	err := c.client.restRequest(context.Background(), http.MethodGet, "api/v1/health-json", nil, "", nil, &resp)
+	var statusErr *statusCodeError
+	if errors.As(err, &statusErr) {
+		if json.Unmarshal(statusErr.Body, &resp) != nil || resp.Status == "" {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	health := &ClusterHealth{
+		Status:        resp.Status,
+		StatusMessage: resp.StatusMessage,
+		Checks:        make([]ClusterHealthCheck, len(resp.Checks)),
+	}
+	for i, check := range resp.Checks {
+		health.Checks[i] = ClusterHealthCheck{
+			Name:          check.Name,
+			Status:        check.Status,
+			StatusMessage: check.StatusMessage,
+		}
+	}
+
+	return health, nil
+}
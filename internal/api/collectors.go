@@ -0,0 +1,438 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// CollectorConfiguration represents a fleet-managed LogCollector configuration
+type CollectorConfiguration struct {
+	ID          string
+	Name        string
+	Description string
+	// YAML is the published configuration body
+	YAML string
+	// Version is bumped by the server every time the configuration is published
+	Version int
+}
+
+// CollectorGroup represents a group of LogCollectors, selected by a filter query, that the assigned configurations
+// are rolled out to
+type CollectorGroup struct {
+	ID               string
+	Name             string
+	Filter           string
+	ConfigurationIDs []string
+}
+
+// Collectors provides operations for managing fleet-managed LogCollector configurations and groups
+type Collectors struct {
+	client *Client
+}
+
+const listCollectorConfigurationsQuery = `
+query ListLogCollectorConfigurations {
+  logCollectorConfigurations {
+    id
+    name
+    description
+    yaml
+    version
+  }
+}
+`
+
+const createCollectorConfigurationMutation = `
+mutation CreateLogCollectorConfiguration($Name: String!, $Draft: String) {
+  createLogCollectorConfiguration(name: $Name, draft: $Draft) {
+    id
+    version
+  }
+}
+`
+
+const updateCollectorConfigurationNameMutation = `
+mutation UpdateLogCollectorConfigurationName($ID: String!, $Name: String!) {
+  updateLogCollectorConfigurationName(configId: $ID, name: $Name) {
+    id
+  }
+}
+`
+
+const updateCollectorConfigurationDescriptionMutation = `
+mutation UpdateLogCollectorConfigurationDescription($ID: String!, $Description: String) {
+  updateLogCollectorConfigurationDescription(configId: $ID, description: $Description) {
+    id
+  }
+}
+`
+
+const publishCollectorConfigurationMutation = `
+mutation PublishLogCollectorConfiguration($ID: String!, $YAML: String, $CurrentVersion: Int!) {
+  publishLogCollectorConfiguration(id: $ID, yaml: $YAML, currentVersion: $CurrentVersion) {
+    version
+  }
+}
+`
+
+const deleteCollectorConfigurationMutation = `
+mutation DeleteLogCollectorConfiguration($ID: String!, $Version: Int!) {
+  deleteLogCollectorConfiguration(configId: $ID, versionId: $Version)
+}
+`
+
+const listCollectorGroupsQuery = `
+query ListLogCollectorGroups {
+  logCollectorGroups {
+    id
+    name
+    filter
+    configurations {
+      id
+    }
+  }
+}
+`
+
+const createCollectorGroupMutation = `
+mutation CreateLogCollectorGroup($Name: String!, $Filter: String, $ConfigurationIDs: [String!]) {
+  createLogCollectorGroup(name: $Name, filter: $Filter, configIds: $ConfigurationIDs) {
+    id
+  }
+}
+`
+
+const updateCollectorGroupNameMutation = `
+mutation UpdateLogCollectorGroupName($ID: String!, $Name: String!) {
+  updateLogCollectorGroupName(id: $ID, name: $Name) {
+    id
+  }
+}
+`
+
+const updateCollectorGroupFilterMutation = `
+mutation UpdateLogCollectorGroupFilter($ID: String!, $Filter: String) {
+  updateLogCollectorGroupFilter(id: $ID, filter: $Filter) {
+    id
+  }
+}
+`
+
+const updateCollectorGroupConfigurationsMutation = `
+mutation UpdateLogCollectorGroupConfigurations($ID: String!, $ConfigurationIDs: [String!]) {
+  updateLogCollectorGroupConfigurations(id: $ID, configIds: $ConfigurationIDs) {
+    id
+  }
+}
+`
+
+const deleteCollectorGroupMutation = `
+mutation DeleteLogCollectorGroup($ID: String!) {
+  deleteLogCollectorGroup(id: $ID)
+}
+`
+
+// listCollectorConfigurationsResponse represents the response from list log collector configurations query
+type listCollectorConfigurationsResponse struct {
+	LogCollectorConfigurations []struct {
+		ID          string  `json:"id"`
+		Name        string  `json:"name"`
+		Description *string `json:"description"`
+		YAML        *string `json:"yaml"`
+		Version     int     `json:"version"`
+	} `json:"logCollectorConfigurations"`
+}
+
+// createCollectorConfigurationResponse represents the response from create log collector configuration mutation
+type createCollectorConfigurationResponse struct {
+	CreateLogCollectorConfiguration struct {
+		ID      string `json:"id"`
+		Version int    `json:"version"`
+	} `json:"createLogCollectorConfiguration"`
+}
+
+// publishCollectorConfigurationResponse represents the response from publish log collector configuration mutation
+type publishCollectorConfigurationResponse struct {
+	PublishLogCollectorConfiguration struct {
+		Version int `json:"version"`
+	} `json:"publishLogCollectorConfiguration"`
+}
+
+// listCollectorGroupsResponse represents the response from list log collector groups query
+type listCollectorGroupsResponse struct {
+	LogCollectorGroups []struct {
+		ID             string  `json:"id"`
+		Name           string  `json:"name"`
+		Filter         *string `json:"filter"`
+		Configurations []struct {
+			ID string `json:"id"`
+		} `json:"configurations"`
+	} `json:"logCollectorGroups"`
+}
+
+// createCollectorGroupResponse represents the response from create log collector group mutation
+type createCollectorGroupResponse struct {
+	CreateLogCollectorGroup struct {
+		ID string `json:"id"`
+	} `json:"createLogCollectorGroup"`
+}
+
+// ListConfigurations returns all LogCollector configurations in the organization
+func (c *Collectors) ListConfigurations() ([]CollectorConfiguration, error) {
+	var resp listCollectorConfigurationsResponse
+	err := c.client.Query(context.Background(), listCollectorConfigurationsQuery, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	configurations := make([]CollectorConfiguration, len(resp.LogCollectorConfigurations))
+	for i, configuration := range resp.LogCollectorConfigurations {
+		configurations[i] = CollectorConfiguration{
+			ID:      configuration.ID,
+			Name:    configuration.Name,
+			Version: configuration.Version,
+		}
+		if configuration.Description != nil {
+			configurations[i].Description = *configuration.Description
+		}
+		if configuration.YAML != nil {
+			configurations[i].YAML = *configuration.YAML
+		}
+	}
+
+	return configurations, nil
+}
+
+// GetConfiguration returns a LogCollector configuration by ID
+func (c *Collectors) GetConfiguration(id string) (*CollectorConfiguration, error) {
+	configurations, err := c.ListConfigurations()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, configuration := range configurations {
+		if configuration.ID == id {
+			return &configuration, nil
+		}
+	}
+
+	return nil, fmt.Errorf("collector configuration not found: %s", id)
+}
+
+// AddConfiguration creates a new LogCollector configuration and publishes its YAML. Should setting the description or
+// publishing fail after the configuration was created, the configuration is returned along with the error, so its ID
+// is not lost.
+func (c *Collectors) AddConfiguration(configuration *CollectorConfiguration) (*CollectorConfiguration, error) {
+	var resp createCollectorConfigurationResponse
+	err := c.client.Query(context.Background(), createCollectorConfigurationMutation, map[string]interface{}{
+		"Name":  configuration.Name,
+		"Draft": configuration.YAML,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	configuration.ID = resp.CreateLogCollectorConfiguration.ID
+	configuration.Version = resp.CreateLogCollectorConfiguration.Version
+
+	if configuration.Description != "" {
+		err = c.updateConfigurationDescription(configuration)
+		if err != nil {
+			return configuration, err
+		}
+	}
+
+	published, err := c.publishConfiguration(configuration)
+	if err != nil {
+		return configuration, err
+	}
+	return published, nil
+}
+
+// UpdateConfiguration updates the name and description of an existing LogCollector configuration, and publishes its
+// YAML if it changed
+func (c *Collectors) UpdateConfiguration(configuration *CollectorConfiguration) (*CollectorConfiguration, error) {
+	current, err := c.GetConfiguration(configuration.ID)
+	if err != nil {
+		return nil, err
+	}
+	configuration.Version = current.Version
+
+	if current.Name != configuration.Name {
+		err = c.client.Query(context.Background(), updateCollectorConfigurationNameMutation, map[string]interface{}{
+			"ID":   configuration.ID,
+			"Name": configuration.Name,
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if current.Description != configuration.Description {
+		err = c.updateConfigurationDescription(configuration)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if current.YAML != configuration.YAML {
+		return c.publishConfiguration(configuration)
+	}
+
+	return configuration, nil
+}
+
+// DeleteConfiguration deletes a LogCollector configuration by ID
+func (c *Collectors) DeleteConfiguration(id string) error {
+	current, err := c.GetConfiguration(id)
+	if err != nil {
+		return err
+	}
+
+	return c.client.Query(context.Background(), deleteCollectorConfigurationMutation, map[string]interface{}{
+		"ID":      id,
+		"Version": current.Version,
+	}, nil)
+}
+
+func (c *Collectors) updateConfigurationDescription(configuration *CollectorConfiguration) error {
+	return c.client.Query(context.Background(), updateCollectorConfigurationDescriptionMutation, map[string]interface{}{
+		"ID":          configuration.ID,
+		"Description": configuration.Description,
+	}, nil)
+}
+
+func (c *Collectors) publishConfiguration(configuration *CollectorConfiguration) (*CollectorConfiguration, error) {
+	var resp publishCollectorConfigurationResponse
+	err := c.client.Query(context.Background(), publishCollectorConfigurationMutation, map[string]interface{}{
+		"ID":             configuration.ID,
+		"YAML":           configuration.YAML,
+		"CurrentVersion": configuration.Version,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	configuration.Version = resp.PublishLogCollectorConfiguration.Version
+	return configuration, nil
+}
+
+// ListGroups returns all LogCollector groups in the organization
+func (c *Collectors) ListGroups() ([]CollectorGroup, error) {
+	var resp listCollectorGroupsResponse
+	err := c.client.Query(context.Background(), listCollectorGroupsQuery, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]CollectorGroup, len(resp.LogCollectorGroups))
+	for i, group := range resp.LogCollectorGroups {
+		configurationIDs := make([]string, len(group.Configurations))
+		for j, configuration := range group.Configurations {
+			configurationIDs[j] = configuration.ID
+		}
+		groups[i] = CollectorGroup{
+			ID:               group.ID,
+			Name:             group.Name,
+			ConfigurationIDs: configurationIDs,
+		}
+		if group.Filter != nil {
+			groups[i].Filter = *group.Filter
+		}
+	}
+
+	return groups, nil
+}
+
+// GetGroup returns a LogCollector group by ID
+func (c *Collectors) GetGroup(id string) (*CollectorGroup, error) {
+	groups, err := c.ListGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range groups {
+		if group.ID == id {
+			return &group, nil
+		}
+	}
+
+	return nil, fmt.Errorf("collector group not found: %s", id)
+}
+
+// AddGroup creates a new LogCollector group
+func (c *Collectors) AddGroup(group *CollectorGroup) (*CollectorGroup, error) {
+	var resp createCollectorGroupResponse
+	err := c.client.Query(context.Background(), createCollectorGroupMutation, map[string]interface{}{
+		"Name":             group.Name,
+		"Filter":           group.Filter,
+		"ConfigurationIDs": group.ConfigurationIDs,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	group.ID = resp.CreateLogCollectorGroup.ID
+	return group, nil
+}
+
+// UpdateGroup updates the name, filter and assigned configurations of an existing LogCollector group in place, only
+// sending the ones that changed
+func (c *Collectors) UpdateGroup(group *CollectorGroup) (*CollectorGroup, error) {
+	current, err := c.GetGroup(group.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if current.Name != group.Name {
+		err = c.client.Query(context.Background(), updateCollectorGroupNameMutation, map[string]interface{}{
+			"ID":   group.ID,
+			"Name": group.Name,
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if current.Filter != group.Filter {
+		err = c.client.Query(context.Background(), updateCollectorGroupFilterMutation, map[string]interface{}{
+			"ID":     group.ID,
+			"Filter": group.Filter,
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !sameStrings(current.ConfigurationIDs, group.ConfigurationIDs) {
+		err = c.client.Query(context.Background(), updateCollectorGroupConfigurationsMutation, map[string]interface{}{
+			"ID":               group.ID,
+			"ConfigurationIDs": group.ConfigurationIDs,
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return group, nil
+}
+
+// sameStrings reports whether a and b hold the same strings, regardless of order
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string(nil), a...)
+	sortedB := append([]string(nil), b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// DeleteGroup deletes a LogCollector group by ID
+func (c *Collectors) DeleteGroup(id string) error {
+	return c.client.Query(context.Background(), deleteCollectorGroupMutation, map[string]interface{}{
+		"ID": id,
+	}, nil)
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"fmt"
+)
+
+// Dynamic configuration value type constants, as declared by the server for each key
+const (
+	DynamicConfigValueTypeBoolean = "Boolean"
+	DynamicConfigValueTypeInteger = "Integer"
+	DynamicConfigValueTypeFloat   = "Float"
+	DynamicConfigValueTypeString  = "String"
+)
+
+// DynamicConfig represents a single dynamic configuration key and its current value. The server reports all values as
+// strings, along with the type the value must have.
+type DynamicConfig struct {
+	Key       string
+	Value     string
+	ValueType string
+}
+
+// DynamicConfigs provides operations for managing the dynamic configuration of a self-hosted cluster
+type DynamicConfigs struct {
+	client *Client
+}
+
+const listDynamicConfigsQuery = `
+query ListDynamicConfigs {
+  dynamicConfigs {
+    dynamicConfigKey
+    dynamicConfigValue
+    dynamicConfigValueType
+  }
+}
+`
+
+const setDynamicConfigMutation = `
+mutation SetDynamicConfig($Config: DynamicConfig!, $Value: String!) {
+  setDynamicConfig(input: {
+    config: $Config
+    value: $Value
+  })
+}
+`
+
+const unsetDynamicConfigMutation = `
+mutation UnsetDynamicConfig($Config: DynamicConfig!) {
+  unsetDynamicConfig(input: {
+    config: $Config
+  })
+}
+`
+
+// listDynamicConfigsResponse represents the response from list dynamic configs query
+type listDynamicConfigsResponse struct {
+	DynamicConfigs []struct {
+		DynamicConfigKey       string `json:"dynamicConfigKey"`
+		DynamicConfigValue     string `json:"dynamicConfigValue"`
+		DynamicConfigValueType string `json:"dynamicConfigValueType"`
+	} `json:"dynamicConfigs"`
+}
+
+// List returns all dynamic configuration keys with their current values
+func (c *DynamicConfigs) List() ([]DynamicConfig, error) {
+	var resp listDynamicConfigsResponse
+	err := c.client.Query(context.Background(), listDynamicConfigsQuery, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	configs := make([]DynamicConfig, len(resp.DynamicConfigs))
+	for i, config := range resp.DynamicConfigs {
+		configs[i] = DynamicConfig{
+			Key:       config.DynamicConfigKey,
+			Value:     config.DynamicConfigValue,
+			ValueType: config.DynamicConfigValueType,
+		}
+	}
+
+	return configs, nil
+}
+
+// Get returns a dynamic configuration by key
+func (c *DynamicConfigs) Get(key string) (*DynamicConfig, error) {
+	configs, err := c.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, config := range configs {
+		if config.Key == key {
+			return &config, nil
+		}
+	}
+
+	return nil, fmt.Errorf("dynamic config not found: %s", key)
+}
+
+// Set changes the value of a dynamic configuration key
+func (c *DynamicConfigs) Set(key, value string) error {
+	variables := map[string]interface{}{
+		"Config": key,
+		"Value":  value,
+	}
+
+	return c.client.Query(context.Background(), setDynamicConfigMutation, variables, nil)
+}
+
+// Unset resets a dynamic configuration key to its default value
+func (c *DynamicConfigs) Unset(key string) error {
+	variables := map[string]interface{}{
+		"Config": key,
+	}
+
+	return c.client.Query(context.Background(), unsetDynamicConfigMutation, variables, nil)
+}
@@ -0,0 +1,445 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Field alias schema field type constants
+const (
+	FieldAliasFieldTypeString  = "string"
+	FieldAliasFieldTypeNumber  = "number"
+	FieldAliasFieldTypeBoolean = "boolean"
+)
+
+// FieldAliasSchema represents a set of normalized field names that source fields can be aliased to
+type FieldAliasSchema struct {
+	ID     string
+	Name   string
+	Fields []FieldAliasSchemaField
+	// Active reports whether the schema is the one enabled on the organization
+	Active bool
+}
+
+// FieldAliasSchemaField represents a field in a field alias schema
+type FieldAliasSchemaField struct {
+	Name string
+	Type string
+}
+
+// FieldAliasMapping maps the fields of events matching a set of tags onto the fields of a schema
+type FieldAliasMapping struct {
+	ID       string
+	SchemaID string
+	Name     string
+	Tags     map[string]string
+	// Aliases maps schema field names to the source field aliased to them
+	Aliases              map[string]string
+	OriginalFieldsToKeep []string
+}
+
+// FieldAliases provides operations for managing field alias schemas and mappings
+type FieldAliases struct {
+	client *Client
+}
+
+const listFieldAliasSchemasQuery = `
+query ListFieldAliasSchemas {
+  fieldAliasSchemasInfo {
+    activeSchemaOnOrg
+    schemas {
+      id
+      name
+      fields {
+        name
+        type
+      }
+      instances {
+        id
+        name
+        tags {
+          name
+          value
+        }
+        aliases {
+          source
+          alias
+        }
+        originalFieldsToKeep
+      }
+    }
+  }
+}
+`
+
+const createFieldAliasSchemaMutation = `
+mutation CreateFieldAliasSchema($Name: String!, $Fields: [SchemaFieldInput!]!) {
+  createFieldAliasSchema(input: {
+    name: $Name
+    fields: $Fields
+  }) {
+    id
+  }
+}
+`
+
+const updateFieldAliasSchemaMutation = `
+mutation UpdateFieldAliasSchema($ID: String!, $Name: String!, $Fields: [SchemaFieldInput!]!) {
+  updateFieldAliasSchema(input: {
+    id: $ID
+    name: $Name
+    fields: $Fields
+  }) {
+    id
+  }
+}
+`
+
+const deleteFieldAliasSchemaMutation = `
+mutation DeleteFieldAliasSchema($ID: String!) {
+  deleteFieldAliasSchema(input: {
+    schemaId: $ID
+  })
+}
+`
+
+const enableFieldAliasSchemaMutation = `
+mutation EnableFieldAliasSchema($ID: String!) {
+  enableFieldAliasSchemaOnOrg(input: {
+    schemaId: $ID
+  })
+}
+`
+
+const disableFieldAliasSchemaMutation = `
+mutation DisableFieldAliasSchema {
+  disableFieldAliasSchemaOnOrg
+}
+`
+
+const createFieldAliasMappingMutation = `
+mutation CreateFieldAliasMapping(
+  $SchemaID: String!
+  $Name: String!
+  $Tags: [TagsInput!]!
+  $Aliases: [AliasInfoInput!]!
+  $OriginalFieldsToKeep: [String!]
+) {
+  createFieldAliasMapping(input: {
+    schemaId: $SchemaID
+    name: $Name
+    tags: $Tags
+    aliases: $Aliases
+    originalFieldsToKeep: $OriginalFieldsToKeep
+  })
+}
+`
+
+const updateFieldAliasMappingMutation = `
+mutation UpdateFieldAliasMapping(
+  $ID: String!
+  $SchemaID: String!
+  $Name: String!
+  $Tags: [TagsInput!]!
+  $Aliases: [AliasInfoInput!]!
+  $OriginalFieldsToKeep: [String!]
+) {
+  updateFieldAliasMapping(input: {
+    aliasMappingId: $ID
+    schemaId: $SchemaID
+    name: $Name
+    tags: $Tags
+    aliases: $Aliases
+    originalFieldsToKeep: $OriginalFieldsToKeep
+  })
+}
+`
+
+const deleteFieldAliasMappingMutation = `
+mutation DeleteFieldAliasMapping($SchemaID: String!, $ID: String!) {
+  deleteFieldAliasMapping(input: {
+    schemaId: $SchemaID
+    aliasMappingId: $ID
+  })
+}
+`
+
+// listFieldAliasSchemasResponse represents the response from list field alias schemas query
+type listFieldAliasSchemasResponse struct {
+	FieldAliasSchemasInfo struct {
+		ActiveSchemaOnOrg *string `json:"activeSchemaOnOrg"`
+		Schemas           []struct {
+			ID     string `json:"id"`
+			Name   string `json:"name"`
+			Fields []struct {
+				Name string `json:"name"`
+				Type string `json:"type"`
+			} `json:"fields"`
+			Instances []struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+				Tags []struct {
+					Name  string `json:"name"`
+					Value string `json:"value"`
+				} `json:"tags"`
+				Aliases []struct {
+					Source string `json:"source"`
+					Alias  string `json:"alias"`
+				} `json:"aliases"`
+				OriginalFieldsToKeep []string `json:"originalFieldsToKeep"`
+			} `json:"instances"`
+		} `json:"schemas"`
+	} `json:"fieldAliasSchemasInfo"`
+}
+
+// createFieldAliasSchemaResponse represents the response from create field alias schema mutation
+type createFieldAliasSchemaResponse struct {
+	CreateFieldAliasSchema struct {
+		ID string `json:"id"`
+	} `json:"createFieldAliasSchema"`
+}
+
+// createFieldAliasMappingResponse represents the response from create field alias mapping mutation
+type createFieldAliasMappingResponse struct {
+	CreateFieldAliasMapping string `json:"createFieldAliasMapping"`
+}
+
+func (f *FieldAliases) list() (*listFieldAliasSchemasResponse, error) {
+	var resp listFieldAliasSchemasResponse
+	err := f.client.Query(context.Background(), listFieldAliasSchemasQuery, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListSchemas returns all field alias schemas in the organization
+func (f *FieldAliases) ListSchemas() ([]FieldAliasSchema, error) {
+	resp, err := f.list()
+	if err != nil {
+		return nil, err
+	}
+
+	info := resp.FieldAliasSchemasInfo
+	schemas := make([]FieldAliasSchema, len(info.Schemas))
+	for i, schema := range info.Schemas {
+		fields := make([]FieldAliasSchemaField, len(schema.Fields))
+		for j, field := range schema.Fields {
+			fields[j] = FieldAliasSchemaField{
+				Name: field.Name,
+				Type: field.Type,
+			}
+		}
+		schemas[i] = FieldAliasSchema{
+			ID:     schema.ID,
+			Name:   schema.Name,
+			Fields: fields,
+			Active: info.ActiveSchemaOnOrg != nil && *info.ActiveSchemaOnOrg == schema.ID,
+		}
+	}
+
+	return schemas, nil
+}
+
+// GetSchema returns a field alias schema by ID
+func (f *FieldAliases) GetSchema(id string) (*FieldAliasSchema, error) {
+	schemas, err := f.ListSchemas()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, schema := range schemas {
+		if schema.ID == id {
+			return &schema, nil
+		}
+	}
+
+	return nil, fmt.Errorf("field alias schema not found: %s", id)
+}
+
+// AddSchema creates a new field alias schema
+func (f *FieldAliases) AddSchema(schema *FieldAliasSchema) (*FieldAliasSchema, error) {
+	var resp createFieldAliasSchemaResponse
+	err := f.client.Query(context.Background(), createFieldAliasSchemaMutation, map[string]interface{}{
+		"Name":   schema.Name,
+		"Fields": fieldAliasSchemaFieldsInput(schema.Fields),
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	schema.ID = resp.CreateFieldAliasSchema.ID
+	return schema, nil
+}
+
+// UpdateSchema updates the name and fields of an existing field alias schema in place
+func (f *FieldAliases) UpdateSchema(schema *FieldAliasSchema) (*FieldAliasSchema, error) {
+	err := f.client.Query(context.Background(), updateFieldAliasSchemaMutation, map[string]interface{}{
+		"ID":     schema.ID,
+		"Name":   schema.Name,
+		"Fields": fieldAliasSchemaFieldsInput(schema.Fields),
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// DeleteSchema deletes a field alias schema, and with it all of its mappings, by ID
+func (f *FieldAliases) DeleteSchema(id string) error {
+	return f.client.Query(context.Background(), deleteFieldAliasSchemaMutation, map[string]interface{}{
+		"ID": id,
+	}, nil)
+}
+
+// EnableSchema makes the given schema the one active on the organization, replacing any other active schema
+func (f *FieldAliases) EnableSchema(id string) error {
+	return f.client.Query(context.Background(), enableFieldAliasSchemaMutation, map[string]interface{}{
+		"ID": id,
+	}, nil)
+}
+
+// DisableSchema deactivates field aliasing on the organization
+func (f *FieldAliases) DisableSchema() error {
+	return f.client.Query(context.Background(), disableFieldAliasSchemaMutation, nil, nil)
+}
+
+// ListMappings returns all mappings of the given field alias schema
+func (f *FieldAliases) ListMappings(schemaID string) ([]FieldAliasMapping, error) {
+	resp, err := f.list()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, schema := range resp.FieldAliasSchemasInfo.Schemas {
+		if schema.ID != schemaID {
+			continue
+		}
+		mappings := make([]FieldAliasMapping, len(schema.Instances))
+		for i, instance := range schema.Instances {
+			tags := make(map[string]string, len(instance.Tags))
+			for _, tag := range instance.Tags {
+				tags[tag.Name] = tag.Value
+			}
+			aliases := make(map[string]string, len(instance.Aliases))
+			for _, alias := range instance.Aliases {
+				aliases[alias.Alias] = alias.Source
+			}
+			mappings[i] = FieldAliasMapping{
+				ID:                   instance.ID,
+				SchemaID:             schemaID,
+				Name:                 instance.Name,
+				Tags:                 tags,
+				Aliases:              aliases,
+				OriginalFieldsToKeep: instance.OriginalFieldsToKeep,
+			}
+		}
+		return mappings, nil
+	}
+
+	return nil, fmt.Errorf("field alias schema not found: %s", schemaID)
+}
+
+// GetMapping returns a field alias mapping by ID
+func (f *FieldAliases) GetMapping(schemaID, id string) (*FieldAliasMapping, error) {
+	mappings, err := f.ListMappings(schemaID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mapping := range mappings {
+		if mapping.ID == id {
+			return &mapping, nil
+		}
+	}
+
+	return nil, fmt.Errorf("field alias mapping not found: %s", id)
+}
+
+// AddMapping creates a new field alias mapping
+func (f *FieldAliases) AddMapping(mapping *FieldAliasMapping) (*FieldAliasMapping, error) {
+	var resp createFieldAliasMappingResponse
+	err := f.client.Query(context.Background(), createFieldAliasMappingMutation, fieldAliasMappingVariables(mapping), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping.ID = resp.CreateFieldAliasMapping
+	return mapping, nil
+}
+
+// UpdateMapping updates an existing field alias mapping in place
+func (f *FieldAliases) UpdateMapping(mapping *FieldAliasMapping) (*FieldAliasMapping, error) {
+	variables := fieldAliasMappingVariables(mapping)
+	variables["ID"] = mapping.ID
+
+	err := f.client.Query(context.Background(), updateFieldAliasMappingMutation, variables, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping, nil
+}
+
+// DeleteMapping deletes a field alias mapping by ID
+func (f *FieldAliases) DeleteMapping(schemaID, id string) error {
+	return f.client.Query(context.Background(), deleteFieldAliasMappingMutation, map[string]interface{}{
+		"SchemaID": schemaID,
+		"ID":       id,
+	}, nil)
+}
+
+func fieldAliasSchemaFieldsInput(fields []FieldAliasSchemaField) []map[string]interface{} {
+	input := make([]map[string]interface{}, len(fields))
+	for i, field := range fields {
+		input[i] = map[string]interface{}{
+			"name":      field.Name,
+			"fieldType": field.Type,
+		}
+	}
+	return input
+}
+
+func fieldAliasMappingVariables(mapping *FieldAliasMapping) map[string]interface{} {
+	// Maps are sent sorted by key, so the server sees the same order for the same configuration
+	tagNames := make([]string, 0, len(mapping.Tags))
+	for name := range mapping.Tags {
+		tagNames = append(tagNames, name)
+	}
+	sort.Strings(tagNames)
+	tags := make([]map[string]interface{}, len(tagNames))
+	for i, name := range tagNames {
+		tags[i] = map[string]interface{}{
+			"name":  name,
+			"value": mapping.Tags[name],
+		}
+	}
+
+	aliasNames := make([]string, 0, len(mapping.Aliases))
+	for alias := range mapping.Aliases {
+		aliasNames = append(aliasNames, alias)
+	}
+	sort.Strings(aliasNames)
+	aliases := make([]map[string]interface{}, len(aliasNames))
+	for i, alias := range aliasNames {
+		aliases[i] = map[string]interface{}{
+			"source": mapping.Aliases[alias],
+			"alias":  alias,
+		}
+	}
+
+	originalFieldsToKeep := mapping.OriginalFieldsToKeep
+	if originalFieldsToKeep == nil {
+		originalFieldsToKeep = []string{}
+	}
+
+	return map[string]interface{}{
+		"SchemaID":             mapping.SchemaID,
+		"Name":                 mapping.Name,
+		"Tags":                 tags,
+		"Aliases":              aliases,
+		"OriginalFieldsToKeep": originalFieldsToKeep,
+	}
+}
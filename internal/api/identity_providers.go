@@ -0,0 +1,438 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+)
+
+// Identity provider type constants, matching the __typename of the providers in the GraphQL API
+const (
+	IdentityProviderTypeSAML = "SamlIdentityProvider"
+	IdentityProviderTypeOIDC = "OidcIdentityProvider"
+)
+
+// OIDC token endpoint authentication method constants
+const (
+	OIDCTokenEndpointAuthMethodClientSecretBasic = "client_secret_basic"
+	OIDCTokenEndpointAuthMethodClientSecretPost  = "client_secret_post"
+)
+
+// SAMLIdentityProvider represents a SAML single sign-on identity provider
+type SAMLIdentityProvider struct {
+	ID      string
+	Name    string
+	Domains []string
+	// IdPCertificate and AlternativeIdPCertificate are PEM-encoded; the alternative certificate allows rotating the
+	// certificate without downtime
+	IdPCertificate            string
+	AlternativeIdPCertificate string
+	IdPEntityID               string
+	SignOnURL                 string
+	UserAttribute             string
+	GroupMembershipAttribute  string
+	LazyCreateUsers           bool
+	DefaultIdP                bool
+	Debug                     bool
+}
+
+// OIDCIdentityProvider represents an OpenID Connect single sign-on identity provider
+type OIDCIdentityProvider struct {
+	ID                      string
+	Name                    string
+	Domains                 []string
+	ClientID                string
+	ClientSecret            string
+	Issuer                  string
+	TokenEndpointAuthMethod string
+	Scopes                  []string
+	UserClaim               string
+	GroupsClaim             string
+	AuthorizationEndpoint   string
+	TokenEndpoint           string
+	UserInfoEndpoint        string
+	JWKSEndpoint            string
+	RegistrationEndpoint    string
+	LazyCreateUsers         bool
+	DefaultIdP              bool
+	Debug                   bool
+}
+
+// IdentityProviders provides operations for managing SAML and OIDC identity providers
+type IdentityProviders struct {
+	client *Client
+}
+
+const listIdentityProvidersQuery = `
+query ListIdentityProviders {
+  identityProviders {
+    __typename
+    id
+    name
+    domains
+    debug
+    defaultIdp
+    lazyCreateUsers
+    ... on SamlIdentityProvider {
+      idpCertificateInBase64
+      alternativeIdpCertificateInBase64
+      idpEntityId
+      signOnUrl
+      userAttribute
+      groupMembershipAttribute
+    }
+    ... on OidcIdentityProvider {
+      clientId
+      clientSecret
+      issuer
+      tokenEndpointAuthMethod
+      scopes
+      userClaim
+      groupsClaim
+      authorizationEndpoint
+      tokenEndpoint
+      userInfoEndpoint
+      jwksEndpoint
+      registrationEndpoint
+    }
+  }
+}
+`
+
+const createSAMLIdentityProviderMutation = `
+mutation CreateSAMLIdentityProvider(
+  $Name: String!
+  $Domains: [String!]!
+  $IdPCertificate: String!
+  $AlternativeIdPCertificate: String
+  $IdPEntityID: String!
+  $SignOnURL: String!
+  $UserAttribute: String
+  $GroupMembershipAttribute: String
+  $LazyCreateUsers: Boolean!
+  $DefaultIdP: Boolean
+  $Debug: Boolean!
+) {
+  createSamlIdentityProvider(
+    name: $Name
+    domains: $Domains
+    idpCertificateInBase64: $IdPCertificate
+    alternativeIdpCertificateInBase64: $AlternativeIdPCertificate
+    idpEntityId: $IdPEntityID
+    signOnUrl: $SignOnURL
+    userAttribute: $UserAttribute
+    groupMembershipAttribute: $GroupMembershipAttribute
+    lazyCreateUsers: $LazyCreateUsers
+    defaultIdp: $DefaultIdP
+    debug: $Debug
+  ) {
+    id
+  }
+}
+`
+
+const updateSAMLIdentityProviderMutation = `
+mutation UpdateSAMLIdentityProvider(
+  $ID: String!
+  $Name: String!
+  $Domains: [String!]!
+  $IdPCertificate: String!
+  $AlternativeIdPCertificate: String
+  $IdPEntityID: String!
+  $SignOnURL: String!
+  $UserAttribute: String
+  $GroupMembershipAttribute: String
+  $LazyCreateUsers: Boolean!
+  $DefaultIdP: Boolean
+  $Debug: Boolean!
+) {
+  updateSamlIdentityProvider(
+    id: $ID
+    name: $Name
+    domains: $Domains
+    idpCertificateInBase64: $IdPCertificate
+    alternativeIdpCertificateInBase64: $AlternativeIdPCertificate
+    idpEntityId: $IdPEntityID
+    signOnUrl: $SignOnURL
+    userAttribute: $UserAttribute
+    groupMembershipAttribute: $GroupMembershipAttribute
+    lazyCreateUsers: $LazyCreateUsers
+    defaultIdp: $DefaultIdP
+    debug: $Debug
+  ) {
+    id
+  }
+}
+`
+
+const createOIDCIdentityProviderMutation = `
+mutation CreateOIDCIdentityProvider($Input: OidcConfigurationInput!) {
+  createOIDCIdentityProvider(input: $Input) {
+    id
+  }
+}
+`
+
+const updateOIDCIdentityProviderMutation = `
+mutation UpdateOIDCIdentityProvider($Input: UpdateOidcConfigurationInput!) {
+  updateOIDCIdentityProvider(input: $Input) {
+    id
+  }
+}
+`
+
+const deleteIdentityProviderMutation = `
+mutation DeleteIdentityProvider($ID: String!) {
+  deleteIdentityProvider(id: $ID)
+}
+`
+
+// identityProviderResponse represents a SAML or OIDC identity provider in the list identity providers query
+type identityProviderResponse struct {
+	Typename        string   `json:"__typename"`
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Domains         []string `json:"domains"`
+	Debug           bool     `json:"debug"`
+	DefaultIdP      bool     `json:"defaultIdp"`
+	LazyCreateUsers bool     `json:"lazyCreateUsers"`
+
+	IdPCertificateInBase64            string  `json:"idpCertificateInBase64"`
+	AlternativeIdPCertificateInBase64 *string `json:"alternativeIdpCertificateInBase64"`
+	IdPEntityID                       string  `json:"idpEntityId"`
+	SignOnURL                         string  `json:"signOnUrl"`
+	UserAttribute                     *string `json:"userAttribute"`
+	GroupMembershipAttribute          *string `json:"groupMembershipAttribute"`
+
+	ClientID                string   `json:"clientId"`
+	ClientSecret            *string  `json:"clientSecret"`
+	Issuer                  string   `json:"issuer"`
+	TokenEndpointAuthMethod string   `json:"tokenEndpointAuthMethod"`
+	Scopes                  []string `json:"scopes"`
+	UserClaim               string   `json:"userClaim"`
+	GroupsClaim             *string  `json:"groupsClaim"`
+	AuthorizationEndpoint   *string  `json:"authorizationEndpoint"`
+	TokenEndpoint           *string  `json:"tokenEndpoint"`
+	UserInfoEndpoint        *string  `json:"userInfoEndpoint"`
+	JWKSEndpoint            string   `json:"jwksEndpoint"`
+	RegistrationEndpoint    *string  `json:"registrationEndpoint"`
+}
+
+// listIdentityProvidersResponse represents the response from list identity providers query
+type listIdentityProvidersResponse struct {
+	IdentityProviders []identityProviderResponse `json:"identityProviders"`
+}
+
+// createSAMLIdentityProviderResponse represents the response from create SAML identity provider mutation
+type createSAMLIdentityProviderResponse struct {
+	CreateSAMLIdentityProvider struct {
+		ID string `json:"id"`
+	} `json:"createSamlIdentityProvider"`
+}
+
+// createOIDCIdentityProviderResponse represents the response from create OIDC identity provider mutation
+type createOIDCIdentityProviderResponse struct {
+	CreateOIDCIdentityProvider struct {
+		ID string `json:"id"`
+	} `json:"createOIDCIdentityProvider"`
+}
+
+func (p *IdentityProviders) get(providerType, id string) (*identityProviderResponse, error) {
+	var resp listIdentityProvidersResponse
+	err := p.client.Query(context.Background(), listIdentityProvidersQuery, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, provider := range resp.IdentityProviders {
+		if provider.ID == id && provider.Typename == providerType {
+			return &provider, nil
+		}
+	}
+
+	return nil, fmt.Errorf("identity provider not found: %s", id)
+}
+
+// GetSAML returns a SAML identity provider by ID
+func (p *IdentityProviders) GetSAML(id string) (*SAMLIdentityProvider, error) {
+	provider, err := p.get(IdentityProviderTypeSAML, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SAMLIdentityProvider{
+		ID:                        provider.ID,
+		Name:                      provider.Name,
+		Domains:                   provider.Domains,
+		IdPCertificate:            decodeCertificate(provider.IdPCertificateInBase64),
+		AlternativeIdPCertificate: decodeCertificate(stringValue(provider.AlternativeIdPCertificateInBase64)),
+		IdPEntityID:               provider.IdPEntityID,
+		SignOnURL:                 provider.SignOnURL,
+		UserAttribute:             stringValue(provider.UserAttribute),
+		GroupMembershipAttribute:  stringValue(provider.GroupMembershipAttribute),
+		LazyCreateUsers:           provider.LazyCreateUsers,
+		DefaultIdP:                provider.DefaultIdP,
+		Debug:                     provider.Debug,
+	}, nil
+}
+
+// AddSAML creates a new SAML identity provider
+func (p *IdentityProviders) AddSAML(provider *SAMLIdentityProvider) (*SAMLIdentityProvider, error) {
+	var resp createSAMLIdentityProviderResponse
+	err := p.client.Query(context.Background(), createSAMLIdentityProviderMutation, samlIdentityProviderVariables(provider), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	provider.ID = resp.CreateSAMLIdentityProvider.ID
+	return provider, nil
+}
+
+// UpdateSAML updates an existing SAML identity provider in place
+func (p *IdentityProviders) UpdateSAML(provider *SAMLIdentityProvider) (*SAMLIdentityProvider, error) {
+	variables := samlIdentityProviderVariables(provider)
+	variables["ID"] = provider.ID
+
+	err := p.client.Query(context.Background(), updateSAMLIdentityProviderMutation, variables, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider, nil
+}
+
+// GetOIDC returns an OIDC identity provider by ID. The client secret is only returned to users allowed to see it.
+func (p *IdentityProviders) GetOIDC(id string) (*OIDCIdentityProvider, error) {
+	provider, err := p.get(IdentityProviderTypeOIDC, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OIDCIdentityProvider{
+		ID:                      provider.ID,
+		Name:                    provider.Name,
+		Domains:                 provider.Domains,
+		ClientID:                provider.ClientID,
+		ClientSecret:            stringValue(provider.ClientSecret),
+		Issuer:                  provider.Issuer,
+		TokenEndpointAuthMethod: provider.TokenEndpointAuthMethod,
+		Scopes:                  provider.Scopes,
+		UserClaim:               provider.UserClaim,
+		GroupsClaim:             stringValue(provider.GroupsClaim),
+		AuthorizationEndpoint:   stringValue(provider.AuthorizationEndpoint),
+		TokenEndpoint:           stringValue(provider.TokenEndpoint),
+		UserInfoEndpoint:        stringValue(provider.UserInfoEndpoint),
+		JWKSEndpoint:            provider.JWKSEndpoint,
+		RegistrationEndpoint:    stringValue(provider.RegistrationEndpoint),
+		LazyCreateUsers:         provider.LazyCreateUsers,
+		DefaultIdP:              provider.DefaultIdP,
+		Debug:                   provider.Debug,
+	}, nil
+}
+
+// AddOIDC creates a new OIDC identity provider
+func (p *IdentityProviders) AddOIDC(provider *OIDCIdentityProvider) (*OIDCIdentityProvider, error) {
+	var resp createOIDCIdentityProviderResponse
+	err := p.client.Query(context.Background(), createOIDCIdentityProviderMutation, map[string]interface{}{
+		"Input": oidcIdentityProviderInput(provider),
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	provider.ID = resp.CreateOIDCIdentityProvider.ID
+	return provider, nil
+}
+
+// UpdateOIDC updates an existing OIDC identity provider in place
+func (p *IdentityProviders) UpdateOIDC(provider *OIDCIdentityProvider) (*OIDCIdentityProvider, error) {
+	input := oidcIdentityProviderInput(provider)
+	input["id"] = provider.ID
+
+	err := p.client.Query(context.Background(), updateOIDCIdentityProviderMutation, map[string]interface{}{
+		"Input": input,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider, nil
+}
+
+// Delete deletes a SAML or OIDC identity provider by ID
+func (p *IdentityProviders) Delete(id string) error {
+	return p.client.Query(context.Background(), deleteIdentityProviderMutation, map[string]interface{}{
+		"ID": id,
+	}, nil)
+}
+
+func samlIdentityProviderVariables(provider *SAMLIdentityProvider) map[string]interface{} {
+	return map[string]interface{}{
+		"Name":                      provider.Name,
+		"Domains":                   provider.Domains,
+		"IdPCertificate":            encodeCertificate(provider.IdPCertificate),
+		"AlternativeIdPCertificate": optionalString(encodeCertificate(provider.AlternativeIdPCertificate)),
+		"IdPEntityID":               provider.IdPEntityID,
+		"SignOnURL":                 provider.SignOnURL,
+		"UserAttribute":             optionalString(provider.UserAttribute),
+		"GroupMembershipAttribute":  optionalString(provider.GroupMembershipAttribute),
+		"LazyCreateUsers":           provider.LazyCreateUsers,
+		"DefaultIdP":                provider.DefaultIdP,
+		"Debug":                     provider.Debug,
+	}
+}
+
+func oidcIdentityProviderInput(provider *OIDCIdentityProvider) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                    provider.Name,
+		"domains":                 provider.Domains,
+		"clientID":                provider.ClientID,
+		"clientSecret":            provider.ClientSecret,
+		"issuer":                  provider.Issuer,
+		"tokenEndpointAuthMethod": provider.TokenEndpointAuthMethod,
+		"scopes":                  provider.Scopes,
+		"userClaim":               provider.UserClaim,
+		"groupsClaim":             optionalString(provider.GroupsClaim),
+		"authorizationEndpoint":   optionalString(provider.AuthorizationEndpoint),
+		"tokenEndpoint":           optionalString(provider.TokenEndpoint),
+		"userInfoEndpoint":        optionalString(provider.UserInfoEndpoint),
+		"jwksEndpoint":            provider.JWKSEndpoint,
+		"registrationEndpoint":    optionalString(provider.RegistrationEndpoint),
+		"lazyCreateUsers":         provider.LazyCreateUsers,
+		"defaultIdp":              provider.DefaultIdP,
+		"debug":                   provider.Debug,
+	}
+}
+
+// encodeCertificate base64 encodes a PEM certificate the way the API expects it
+func encodeCertificate(certificate string) string {
+	if certificate == "" {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString([]byte(certificate))
+}
+
+// decodeCertificate reverses encodeCertificate, leaving certificates that were not base64 encoded as they are
+func decodeCertificate(certificate string) string {
+	decoded, err := base64.StdEncoding.DecodeString(certificate)
+	if err != nil {
+		return certificate
+	}
+	return string(decoded)
+}
+
+// stringValue returns the value of an optional string in a response, or the empty string if it is null
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// optionalString sends empty strings as null, for optional arguments the API rejects empty strings for
+func optionalString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,281 @@
+package api
+
+import (
+	"context"
+	"fmt"
+)
+
+// Ingest feed compression constants
+const (
+	IngestFeedCompressionAuto = "Auto"
+	IngestFeedCompressionGzip = "Gzip"
+	IngestFeedCompressionNone = "None"
+)
+
+// Ingest feed preprocessing constants
+const (
+	IngestFeedPreprocessingSplitNewline    = "SplitNewline"
+	IngestFeedPreprocessingSplitAwsRecords = "SplitAwsRecords"
+)
+
+// IngestFeed represents a Humio ingest feed pulling data from AWS S3 through SQS notifications
+type IngestFeed struct {
+	ID            string
+	Name          string
+	Description   string
+	Parser        string
+	RoleArn       string
+	SqsURL        string
+	Region        string
+	Compression   string
+	Preprocessing string
+	Enabled       bool
+}
+
+// IngestFeeds provides operations for managing ingest feeds
+type IngestFeeds struct {
+	client *Client
+}
+
+const listIngestFeedsQuery = `
+query ListIngestFeeds($RepositoryName: RepoOrViewName!) {
+  ingestFeeds(repositoryName: $RepositoryName) {
+    results {
+      id
+      name
+      description
+      parser {
+        name
+      }
+      enabled
+      compression
+      preprocessing {
+        kind
+      }
+      source {
+        __typename
+        ... on AwsS3SqsQueue {
+          sqsUrl
+          region
+          authentication {
+            __typename
+            ... on IngestFeedAwsAuthenticationIamRole {
+              roleArn
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+const createIngestFeedMutation = `
+mutation CreateIngestFeed(
+  $RepositoryName: RepoOrViewName!
+  $Name: String!
+  $Description: String
+  $Parser: String!
+  $Source: IngestFeedSourceInput!
+  $Enabled: Boolean!
+  $Preprocessing: IngestFeedPreprocessingInput!
+  $Compression: IngestFeedCompression!
+) {
+  createIngestFeed(input: {
+    repositoryName: $RepositoryName
+    name: $Name
+    description: $Description
+    parser: $Parser
+    source: $Source
+    enabled: $Enabled
+    preprocessing: $Preprocessing
+    compression: $Compression
+  }) {
+    id
+    name
+  }
+}
+`
+
+const updateIngestFeedMutation = `
+mutation UpdateIngestFeed(
+  $RepositoryName: RepoOrViewName!
+  $ID: String!
+  $Name: String!
+  $Description: String
+  $Parser: String!
+  $Source: IngestFeedSourceInput!
+  $Enabled: Boolean!
+  $Preprocessing: IngestFeedPreprocessingInput!
+  $Compression: IngestFeedCompression!
+) {
+  updateIngestFeed(input: {
+    repositoryName: $RepositoryName
+    id: $ID
+    name: $Name
+    description: $Description
+    parser: $Parser
+    source: $Source
+    enabled: $Enabled
+    preprocessing: $Preprocessing
+    compression: $Compression
+  }) {
+    id
+    name
+  }
+}
+`
+
+const deleteIngestFeedMutation = `
+mutation DeleteIngestFeed($RepositoryName: RepoOrViewName!, $ID: String!) {
+  deleteIngestFeed(repositoryName: $RepositoryName, id: $ID)
+}
+`
+
+// listIngestFeedsResponse represents the response from list ingest feeds query
+type listIngestFeedsResponse struct {
+	IngestFeeds struct {
+		Results []struct {
+			ID          string `json:"id"`
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			Parser      *struct {
+				Name string `json:"name"`
+			} `json:"parser"`
+			Enabled       bool   `json:"enabled"`
+			Compression   string `json:"compression"`
+			Preprocessing struct {
+				Kind string `json:"kind"`
+			} `json:"preprocessing"`
+			Source struct {
+				Typename       string `json:"__typename"`
+				SqsURL         string `json:"sqsUrl"`
+				Region         string `json:"region"`
+				Authentication struct {
+					Typename string `json:"__typename"`
+					RoleArn  string `json:"roleArn"`
+				} `json:"authentication"`
+			} `json:"source"`
+		} `json:"results"`
+	} `json:"ingestFeeds"`
+}
+
+// createIngestFeedResponse represents the response from create ingest feed mutation
+type createIngestFeedResponse struct {
+	CreateIngestFeed struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"createIngestFeed"`
+}
+
+// List returns all ingest feeds for the given repository
+func (f *IngestFeeds) List(repository string) ([]IngestFeed, error) {
+	var resp listIngestFeedsResponse
+	err := f.client.Query(context.Background(), listIngestFeedsQuery, map[string]interface{}{
+		"RepositoryName": repository,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	feeds := make([]IngestFeed, len(resp.IngestFeeds.Results))
+	for i, feed := range resp.IngestFeeds.Results {
+		parser := ""
+		if feed.Parser != nil {
+			parser = feed.Parser.Name
+		}
+		feeds[i] = IngestFeed{
+			ID:            feed.ID,
+			Name:          feed.Name,
+			Description:   feed.Description,
+			Parser:        parser,
+			RoleArn:       feed.Source.Authentication.RoleArn,
+			SqsURL:        feed.Source.SqsURL,
+			Region:        feed.Source.Region,
+			Compression:   feed.Compression,
+			Preprocessing: feed.Preprocessing.Kind,
+			Enabled:       feed.Enabled,
+		}
+	}
+
+	return feeds, nil
+}
+
+// Get returns an ingest feed by ID
+func (f *IngestFeeds) Get(repository, id string) (*IngestFeed, error) {
+	feeds, err := f.List(repository)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, feed := range feeds {
+		if feed.ID == id {
+			return &feed, nil
+		}
+	}
+
+	return nil, fmt.Errorf("ingest feed not found: %s", id)
+}
+
+// Add creates a new ingest feed
+func (f *IngestFeeds) Add(repository string, feed *IngestFeed) (*IngestFeed, error) {
+	variables := ingestFeedVariables(repository, feed)
+
+	var resp createIngestFeedResponse
+	err := f.client.Query(context.Background(), createIngestFeedMutation, variables, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	feed.ID = resp.CreateIngestFeed.ID
+	return feed, nil
+}
+
+// Update updates an existing ingest feed in place
+func (f *IngestFeeds) Update(repository string, feed *IngestFeed) (*IngestFeed, error) {
+	variables := ingestFeedVariables(repository, feed)
+	variables["ID"] = feed.ID
+
+	err := f.client.Query(context.Background(), updateIngestFeedMutation, variables, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return feed, nil
+}
+
+// Delete deletes an ingest feed by ID
+func (f *IngestFeeds) Delete(repository, id string) error {
+	return f.client.Query(context.Background(), deleteIngestFeedMutation, map[string]interface{}{
+		"RepositoryName": repository,
+		"ID":             id,
+	}, nil)
+}
+
+func ingestFeedVariables(repository string, feed *IngestFeed) map[string]interface{} {
+	variables := map[string]interface{}{
+		"RepositoryName": repository,
+		"Name":           feed.Name,
+		"Parser":         feed.Parser,
+		"Source": map[string]interface{}{
+			"type": "AwsS3SqsQueue",
+			"awsS3SqsQueue": map[string]interface{}{
+				"sqsUrl": feed.SqsURL,
+				"region": feed.Region,
+				"authentication": map[string]interface{}{
+					"kind":    "IamRole",
+					"roleArn": feed.RoleArn,
+				},
+			},
+		},
+		"Enabled": feed.Enabled,
+		"Preprocessing": map[string]interface{}{
+			"kind": feed.Preprocessing,
+		},
+		"Compression": feed.Compression,
+	}
+	if feed.Description != "" {
+		variables["Description"] = feed.Description
+	}
+	return variables
+}
@@ -0,0 +1,411 @@
+package api
+
+import (
+	"context"
+	"fmt"
+)
+
+// Interaction type constants
+const (
+	InteractionTypeCustomLink    = "CustomLinkInteraction"
+	InteractionTypeSearchLink    = "SearchLinkInteraction"
+	InteractionTypeDashboardLink = "DashboardLinkInteraction"
+)
+
+// Interaction condition operator constants
+const (
+	InteractionConditionOperatorEqual       = "Equal"
+	InteractionConditionOperatorNotEqual    = "NotEqual"
+	InteractionConditionOperatorContains    = "Contains"
+	InteractionConditionOperatorNotContains = "NotContains"
+	InteractionConditionOperatorStartsWith  = "StartsWith"
+	InteractionConditionOperatorEndsWith    = "EndsWith"
+	InteractionConditionOperatorPresent     = "Present"
+	InteractionConditionOperatorNotPresent  = "NotPresent"
+)
+
+// Interaction represents an event list interaction in a Humio view, e.g. a link to a search or dashboard
+type Interaction struct {
+	Type                     string
+	ID                       string
+	Name                     string
+	TitleTemplate            string
+	Description              string
+	Conditions               []InteractionCondition
+	CustomLinkInteraction    CustomLinkInteraction
+	SearchLinkInteraction    SearchLinkInteraction
+	DashboardLinkInteraction DashboardLinkInteraction
+}
+
+// InteractionCondition limits which fields of an event an interaction is shown for
+type InteractionCondition struct {
+	FieldName string
+	Operator  string
+	Argument  string
+}
+
+// InteractionArgument represents a named argument passed on by a search or dashboard link interaction
+type InteractionArgument struct {
+	Name  string
+	Value string
+}
+
+// CustomLinkInteraction represents an interaction opening a URL
+type CustomLinkInteraction struct {
+	URLTemplate   string
+	OpenInNewTab  bool
+	URLEncodeArgs bool
+}
+
+// SearchLinkInteraction represents an interaction running a search
+type SearchLinkInteraction struct {
+	QueryString         string
+	RepoOrViewName      string
+	Arguments           []InteractionArgument
+	OpenInNewTab        bool
+	UseWidgetTimeWindow bool
+}
+
+// DashboardLinkInteraction represents an interaction opening a dashboard with its parameters set
+type DashboardLinkInteraction struct {
+	DashboardName       string
+	RepoOrViewName      string
+	Arguments           []InteractionArgument
+	OpenInNewTab        bool
+	UseWidgetTimeWindow bool
+}
+
+// Interactions provides operations for managing interactions
+type Interactions struct {
+	client *Client
+}
+
+const listInteractionsQuery = `
+query ListInteractions($SearchDomainName: String!) {
+  searchDomain(name: $SearchDomainName) {
+    interactions {
+      id
+      name
+      titleTemplate
+      description
+      fieldInteractionConditions {
+        fieldName
+        operator
+        argument
+      }
+      typeInfo {
+        __typename
+        ... on CustomLinkInteraction {
+          urlTemplate
+          openInNewTab
+          urlEncodeArgs
+        }
+        ... on SearchLinkInteraction {
+          queryString
+          repoOrViewName
+          arguments {
+            name
+            value
+          }
+          openInNewTab
+          useWidgetTimeWindow
+        }
+        ... on DashboardLinkInteraction {
+          dashboardName
+          repoOrViewName
+          arguments {
+            name
+            value
+          }
+          openInNewTab
+          useWidgetTimeWindow
+        }
+      }
+    }
+  }
+}
+`
+
+const createInteractionMutation = `
+mutation CreateInteraction(
+  $SearchDomainName: String!
+  $Name: String!
+  $TitleTemplate: String
+  $Description: String
+  $Conditions: [FieldInteractionConditionInput!]!
+  $TypeInfo: InteractionTypeInput!
+) {
+  createViewInteraction(input: {
+    viewName: $SearchDomainName
+    name: $Name
+    titleTemplate: $TitleTemplate
+    description: $Description
+    fieldInteractionConditions: $Conditions
+    interactionTypeInfo: $TypeInfo
+  }) {
+    id
+  }
+}
+`
+
+const updateInteractionMutation = `
+mutation UpdateInteraction(
+  $ID: String!
+  $SearchDomainName: String!
+  $Name: String!
+  $TitleTemplate: String
+  $Description: String
+  $Conditions: [FieldInteractionConditionInput!]!
+  $TypeInfo: InteractionTypeInput!
+) {
+  updateViewInteraction(input: {
+    id: $ID
+    viewName: $SearchDomainName
+    name: $Name
+    titleTemplate: $TitleTemplate
+    description: $Description
+    fieldInteractionConditions: $Conditions
+    interactionTypeInfo: $TypeInfo
+  }) {
+    id
+  }
+}
+`
+
+const deleteInteractionMutation = `
+mutation DeleteInteraction($SearchDomainName: String!, $ID: String!) {
+  deleteViewInteraction(input: {
+    viewName: $SearchDomainName
+    id: $ID
+  })
+}
+`
+
+// interactionArgumentResponse represents an argument in the list interactions response
+type interactionArgumentResponse struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// listInteractionsResponse represents the response from list interactions query
+type listInteractionsResponse struct {
+	SearchDomain struct {
+		Interactions []struct {
+			ID                         string `json:"id"`
+			Name                       string `json:"name"`
+			TitleTemplate              string `json:"titleTemplate"`
+			Description                string `json:"description"`
+			FieldInteractionConditions []struct {
+				FieldName string `json:"fieldName"`
+				Operator  string `json:"operator"`
+				Argument  string `json:"argument"`
+			} `json:"fieldInteractionConditions"`
+			TypeInfo struct {
+				Typename            string                        `json:"__typename"`
+				URLTemplate         string                        `json:"urlTemplate"`
+				URLEncodeArgs       bool                          `json:"urlEncodeArgs"`
+				QueryString         string                        `json:"queryString"`
+				DashboardName       string                        `json:"dashboardName"`
+				RepoOrViewName      string                        `json:"repoOrViewName"`
+				Arguments           []interactionArgumentResponse `json:"arguments"`
+				OpenInNewTab        bool                          `json:"openInNewTab"`
+				UseWidgetTimeWindow bool                          `json:"useWidgetTimeWindow"`
+			} `json:"typeInfo"`
+		} `json:"interactions"`
+	} `json:"searchDomain"`
+}
+
+// createInteractionResponse represents the response from create interaction mutation
+type createInteractionResponse struct {
+	CreateViewInteraction struct {
+		ID string `json:"id"`
+	} `json:"createViewInteraction"`
+}
+
+// List returns all interactions for the given view
+func (i *Interactions) List(viewName string) ([]Interaction, error) {
+	var resp listInteractionsResponse
+	err := i.client.Query(context.Background(), listInteractionsQuery, map[string]interface{}{
+		"SearchDomainName": viewName,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	interactions := make([]Interaction, len(resp.SearchDomain.Interactions))
+	for idx, interaction := range resp.SearchDomain.Interactions {
+		conditions := make([]InteractionCondition, len(interaction.FieldInteractionConditions))
+		for j, condition := range interaction.FieldInteractionConditions {
+			conditions[j] = InteractionCondition{
+				FieldName: condition.FieldName,
+				Operator:  condition.Operator,
+				Argument:  condition.Argument,
+			}
+		}
+		arguments := make([]InteractionArgument, len(interaction.TypeInfo.Arguments))
+		for j, argument := range interaction.TypeInfo.Arguments {
+			arguments[j] = InteractionArgument{
+				Name:  argument.Name,
+				Value: argument.Value,
+			}
+		}
+
+		typeInfo := interaction.TypeInfo
+		interactions[idx] = Interaction{
+			Type:          typeInfo.Typename,
+			ID:            interaction.ID,
+			Name:          interaction.Name,
+			TitleTemplate: interaction.TitleTemplate,
+			Description:   interaction.Description,
+			Conditions:    conditions,
+		}
+		switch typeInfo.Typename {
+		case InteractionTypeCustomLink:
+			interactions[idx].CustomLinkInteraction = CustomLinkInteraction{
+				URLTemplate:   typeInfo.URLTemplate,
+				OpenInNewTab:  typeInfo.OpenInNewTab,
+				URLEncodeArgs: typeInfo.URLEncodeArgs,
+			}
+		case InteractionTypeSearchLink:
+			interactions[idx].SearchLinkInteraction = SearchLinkInteraction{
+				QueryString:         typeInfo.QueryString,
+				RepoOrViewName:      typeInfo.RepoOrViewName,
+				Arguments:           arguments,
+				OpenInNewTab:        typeInfo.OpenInNewTab,
+				UseWidgetTimeWindow: typeInfo.UseWidgetTimeWindow,
+			}
+		case InteractionTypeDashboardLink:
+			interactions[idx].DashboardLinkInteraction = DashboardLinkInteraction{
+				DashboardName:       typeInfo.DashboardName,
+				RepoOrViewName:      typeInfo.RepoOrViewName,
+				Arguments:           arguments,
+				OpenInNewTab:        typeInfo.OpenInNewTab,
+				UseWidgetTimeWindow: typeInfo.UseWidgetTimeWindow,
+			}
+		}
+	}
+
+	return interactions, nil
+}
+
+// Get returns an interaction by ID
+func (i *Interactions) Get(viewName, id string) (*Interaction, error) {
+	interactions, err := i.List(viewName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, interaction := range interactions {
+		if interaction.ID == id {
+			return &interaction, nil
+		}
+	}
+
+	return nil, fmt.Errorf("interaction not found: %s", id)
+}
+
+// Add creates a new interaction
+func (i *Interactions) Add(viewName string, interaction *Interaction) (*Interaction, error) {
+	variables, err := interactionVariables(viewName, interaction)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp createInteractionResponse
+	err = i.client.Query(context.Background(), createInteractionMutation, variables, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction.ID = resp.CreateViewInteraction.ID
+	return interaction, nil
+}
+
+// Update updates an existing interaction in place
+func (i *Interactions) Update(viewName string, interaction *Interaction) (*Interaction, error) {
+	variables, err := interactionVariables(viewName, interaction)
+	if err != nil {
+		return nil, err
+	}
+	variables["ID"] = interaction.ID
+
+	err = i.client.Query(context.Background(), updateInteractionMutation, variables, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return interaction, nil
+}
+
+// Delete deletes an interaction by ID
+func (i *Interactions) Delete(viewName, id string) error {
+	return i.client.Query(context.Background(), deleteInteractionMutation, map[string]interface{}{
+		"SearchDomainName": viewName,
+		"ID":               id,
+	}, nil)
+}
+
+func interactionVariables(viewName string, interaction *Interaction) (map[string]interface{}, error) {
+	conditions := make([]map[string]interface{}, len(interaction.Conditions))
+	for idx, condition := range interaction.Conditions {
+		conditions[idx] = map[string]interface{}{
+			"fieldName": condition.FieldName,
+			"operator":  condition.Operator,
+			"argument":  condition.Argument,
+		}
+	}
+
+	var typeInfo map[string]interface{}
+	switch interaction.Type {
+	case InteractionTypeCustomLink:
+		typeInfo = map[string]interface{}{
+			"customLinkInteractionInput": map[string]interface{}{
+				"urlTemplate":   interaction.CustomLinkInteraction.URLTemplate,
+				"openInNewTab":  interaction.CustomLinkInteraction.OpenInNewTab,
+				"urlEncodeArgs": interaction.CustomLinkInteraction.URLEncodeArgs,
+			},
+		}
+	case InteractionTypeSearchLink:
+		typeInfo = map[string]interface{}{
+			"searchLinkInteractionInput": map[string]interface{}{
+				"queryString":         interaction.SearchLinkInteraction.QueryString,
+				"repoOrViewName":      interaction.SearchLinkInteraction.RepoOrViewName,
+				"arguments":           interactionArgumentsInput(interaction.SearchLinkInteraction.Arguments),
+				"openInNewTab":        interaction.SearchLinkInteraction.OpenInNewTab,
+				"useWidgetTimeWindow": interaction.SearchLinkInteraction.UseWidgetTimeWindow,
+			},
+		}
+	case InteractionTypeDashboardLink:
+		typeInfo = map[string]interface{}{
+			"dashboardLinkInteractionInput": map[string]interface{}{
+				"dashboardName":       interaction.DashboardLinkInteraction.DashboardName,
+				"repoOrViewName":      interaction.DashboardLinkInteraction.RepoOrViewName,
+				"arguments":           interactionArgumentsInput(interaction.DashboardLinkInteraction.Arguments),
+				"openInNewTab":        interaction.DashboardLinkInteraction.OpenInNewTab,
+				"useWidgetTimeWindow": interaction.DashboardLinkInteraction.UseWidgetTimeWindow,
+			},
+		}
+	default:
+		return nil, fmt.Errorf("unsupported interaction type: %s", interaction.Type)
+	}
+
+	return map[string]interface{}{
+		"SearchDomainName": viewName,
+		"Name":             interaction.Name,
+		"TitleTemplate":    interaction.TitleTemplate,
+		"Description":      interaction.Description,
+		"Conditions":       conditions,
+		"TypeInfo":         typeInfo,
+	}, nil
+}
+
+func interactionArgumentsInput(arguments []InteractionArgument) []map[string]interface{} {
+	input := make([]map[string]interface{}, len(arguments))
+	for idx, argument := range arguments {
+		input[idx] = map[string]interface{}{
+			"name":  argument.Name,
+			"value": argument.Value,
+		}
+	}
+	return input
+}
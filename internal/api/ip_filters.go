@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// IP filter rule action constants
+const (
+	IPFilterActionAllow = "allow"
+	IPFilterActionDeny  = "deny"
+)
+
+// IPFilterAddressAll matches every address in an IP filter rule
+const IPFilterAddressAll = "all"
+
+// IPFilterRule is a single allow or deny rule. Rules are evaluated in order, and the first matching rule applies.
+type IPFilterRule struct {
+	Action  string
+	Address string
+}
+
+// IPFilter represents a named, ordered list of IP rules used to restrict access to e.g. shared dashboards
+type IPFilter struct {
+	ID    string
+	Name  string
+	Rules []IPFilterRule
+}
+
+// IPFilters provides operations for managing IP filters
+type IPFilters struct {
+	client *Client
+}
+
+const listIPFiltersQuery = `
+query ListIPFilters {
+  ipFilters {
+    id
+    name
+    ipFilter
+  }
+}
+`
+
+const createIPFilterMutation = `
+mutation CreateIPFilter($Name: String!, $IPFilter: String!) {
+  createIPFilter(input: {
+    name: $Name
+    ipFilter: $IPFilter
+  }) {
+    id
+    name
+    ipFilter
+  }
+}
+`
+
+const updateIPFilterMutation = `
+mutation UpdateIPFilter($ID: String!, $Name: String!, $IPFilter: String!) {
+  updateIPFilter(input: {
+    id: $ID
+    name: $Name
+    ipFilter: $IPFilter
+  }) {
+    id
+  }
+}
+`
+
+const deleteIPFilterMutation = `
+mutation DeleteIPFilter($ID: String!) {
+  deleteIPFilter(input: {
+    id: $ID
+  })
+}
+`
+
+// listIPFiltersResponse represents the response from list IP filters query
+type listIPFiltersResponse struct {
+	IPFilters []struct {
+		ID       string `json:"id"`
+		Name     string `json:"name"`
+		IPFilter string `json:"ipFilter"`
+	} `json:"ipFilters"`
+}
+
+// createIPFilterResponse represents the response from create IP filter mutation
+type createIPFilterResponse struct {
+	CreateIPFilter struct {
+		ID string `json:"id"`
+	} `json:"createIPFilter"`
+}
+
+// List returns all IP filters in the organization
+func (f *IPFilters) List() ([]IPFilter, error) {
+	var resp listIPFiltersResponse
+	err := f.client.Query(context.Background(), listIPFiltersQuery, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := make([]IPFilter, len(resp.IPFilters))
+	for i, filter := range resp.IPFilters {
+		filters[i] = IPFilter{
+			ID:    filter.ID,
+			Name:  filter.Name,
+			Rules: parseIPFilterRules(filter.IPFilter),
+		}
+	}
+
+	return filters, nil
+}
+
+// Get returns an IP filter by ID
+func (f *IPFilters) Get(id string) (*IPFilter, error) {
+	filters, err := f.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, filter := range filters {
+		if filter.ID == id {
+			return &filter, nil
+		}
+	}
+
+	return nil, fmt.Errorf("IP filter not found: %s", id)
+}
+
+// Add creates a new IP filter
+func (f *IPFilters) Add(filter *IPFilter) (*IPFilter, error) {
+	var resp createIPFilterResponse
+	err := f.client.Query(context.Background(), createIPFilterMutation, map[string]interface{}{
+		"Name":     filter.Name,
+		"IPFilter": formatIPFilterRules(filter.Rules),
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.ID = resp.CreateIPFilter.ID
+	return filter, nil
+}
+
+// Update updates an existing IP filter in place
+func (f *IPFilters) Update(filter *IPFilter) (*IPFilter, error) {
+	err := f.client.Query(context.Background(), updateIPFilterMutation, map[string]interface{}{
+		"ID":       filter.ID,
+		"Name":     filter.Name,
+		"IPFilter": formatIPFilterRules(filter.Rules),
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+// Delete deletes an IP filter by ID
+func (f *IPFilters) Delete(id string) error {
+	return f.client.Query(context.Background(), deleteIPFilterMutation, map[string]interface{}{
+		"ID": id,
+	}, nil)
+}
+
+// formatIPFilterRules encodes rules the way the API expects them, one "<action> <address>" rule per line
+func formatIPFilterRules(rules []IPFilterRule) string {
+	lines := make([]string, len(rules))
+	for i, rule := range rules {
+		lines[i] = fmt.Sprintf("%s %s", rule.Action, rule.Address)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func parseIPFilterRules(ipFilter string) []IPFilterRule {
+	var rules []IPFilterRule
+	for _, line := range strings.Split(ipFilter, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		rules = append(rules, IPFilterRule{
+			Action:  strings.ToLower(fields[0]),
+			Address: fields[1],
+		})
+	}
+	return rules
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"context"
+)
+
+// TokenSecurityPolicy controls whether a type of token can be created, and for how long
+type TokenSecurityPolicy struct {
+	Enabled bool
+	// MaxTTL is the longest time in milliseconds a token may be valid for, or 0 if tokens may never expire
+	MaxTTL int64
+	// AllowPermissionUpdates reports whether the permissions of existing tokens can be changed. It does not apply to
+	// personal user tokens.
+	AllowPermissionUpdates bool
+}
+
+// OrganizationSettings represents the organization-wide security settings
+type OrganizationSettings struct {
+	PersonalUserTokens           TokenSecurityPolicy
+	ViewPermissionTokens         TokenSecurityPolicy
+	OrganizationPermissionTokens TokenSecurityPolicy
+	SystemPermissionTokens       TokenSecurityPolicy
+	SharedDashboardsEnabled      bool
+	// SharedDashboardsIPFilterID is the IP filter enforced on all shared dashboard links, if any
+	SharedDashboardsIPFilterID string
+	// DefaultRoleID is the role given to new users, if any
+	DefaultRoleID string
+}
+
+// Organization provides operations for managing the organization-wide settings
+type Organization struct {
+	client *Client
+}
+
+const getOrganizationSettingsQuery = `
+query GetOrganizationSettings {
+  tokenSecurityPolicies {
+    personalUserTokensEnabled
+    personalUserTokensEnforceExpirationAfterMs
+    viewPermissionTokensEnabled
+    viewPermissionTokensEnforceExpirationAfterMs
+    viewPermissionTokensAllowPermissionUpdates
+    organizationPermissionTokensEnabled
+    organizationPermissionTokensEnforceExpirationAfterMs
+    organizationPermissionTokensAllowPermissionUpdates
+    systemPermissionTokensEnabled
+    systemPermissionTokensEnforceExpirationAfterMs
+    systemPermissionTokensAllowPermissionUpdates
+  }
+  sharedDashboardsSecurityPolicies {
+    sharedDashboardsEnabled
+    enforceIpFilterId
+  }
+  organization {
+    defaultRole {
+      id
+    }
+  }
+}
+`
+
+const updateTokenSecurityPoliciesMutation = `
+mutation UpdateTokenSecurityPolicies($Input: TokenSecurityPoliciesInput!) {
+  updateTokenSecurityPolicies(input: $Input) {
+    __typename
+  }
+}
+`
+
+const updateSharedDashboardsSecurityPoliciesMutation = `
+mutation UpdateSharedDashboardsSecurityPolicies($Enabled: Boolean!, $IPFilterID: String) {
+  updateSharedDashboardsSecurityPolicies(input: {
+    sharedDashboardsEnabled: $Enabled
+    enforceIpFilterId: $IPFilterID
+  }) {
+    __typename
+  }
+}
+`
+
+const updateDefaultRoleMutation = `
+mutation UpdateDefaultRole($RoleID: String) {
+  updateDefaultRole(input: {
+    roleId: $RoleID
+  }) {
+    __typename
+  }
+}
+`
+
+// getOrganizationSettingsResponse represents the response from get organization settings query
+type getOrganizationSettingsResponse struct {
+	TokenSecurityPolicies struct {
+		PersonalUserTokensEnabled                            bool   `json:"personalUserTokensEnabled"`
+		PersonalUserTokensEnforceExpirationAfterMs           *int64 `json:"personalUserTokensEnforceExpirationAfterMs"`
+		ViewPermissionTokensEnabled                          bool   `json:"viewPermissionTokensEnabled"`
+		ViewPermissionTokensEnforceExpirationAfterMs         *int64 `json:"viewPermissionTokensEnforceExpirationAfterMs"`
+		ViewPermissionTokensAllowPermissionUpdates           bool   `json:"viewPermissionTokensAllowPermissionUpdates"`
+		OrganizationPermissionTokensEnabled                  bool   `json:"organizationPermissionTokensEnabled"`
+		OrganizationPermissionTokensEnforceExpirationAfterMs *int64 `json:"organizationPermissionTokensEnforceExpirationAfterMs"`
+		OrganizationPermissionTokensAllowPermissionUpdates   bool   `json:"organizationPermissionTokensAllowPermissionUpdates"`
+		SystemPermissionTokensEnabled                        bool   `json:"systemPermissionTokensEnabled"`
+		SystemPermissionTokensEnforceExpirationAfterMs       *int64 `json:"systemPermissionTokensEnforceExpirationAfterMs"`
+		SystemPermissionTokensAllowPermissionUpdates         bool   `json:"systemPermissionTokensAllowPermissionUpdates"`
+	} `json:"tokenSecurityPolicies"`
+	SharedDashboardsSecurityPolicies struct {
+		SharedDashboardsEnabled bool    `json:"sharedDashboardsEnabled"`
+		EnforceIPFilterID       *string `json:"enforceIpFilterId"`
+	} `json:"sharedDashboardsSecurityPolicies"`
+	Organization struct {
+		DefaultRole *struct {
+			ID string `json:"id"`
+		} `json:"defaultRole"`
+	} `json:"organization"`
+}
+
+// GetSettings returns the current organization settings
+func (o *Organization) GetSettings() (*OrganizationSettings, error) {
+	var resp getOrganizationSettingsResponse
+	err := o.client.Query(context.Background(), getOrganizationSettingsQuery, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := resp.TokenSecurityPolicies
+	settings := &OrganizationSettings{
+		PersonalUserTokens: TokenSecurityPolicy{
+			Enabled: policies.PersonalUserTokensEnabled,
+			MaxTTL:  int64Value(policies.PersonalUserTokensEnforceExpirationAfterMs),
+		},
+		ViewPermissionTokens: TokenSecurityPolicy{
+			Enabled:                policies.ViewPermissionTokensEnabled,
+			MaxTTL:                 int64Value(policies.ViewPermissionTokensEnforceExpirationAfterMs),
+			AllowPermissionUpdates: policies.ViewPermissionTokensAllowPermissionUpdates,
+		},
+		OrganizationPermissionTokens: TokenSecurityPolicy{
+			Enabled:                policies.OrganizationPermissionTokensEnabled,
+			MaxTTL:                 int64Value(policies.OrganizationPermissionTokensEnforceExpirationAfterMs),
+			AllowPermissionUpdates: policies.OrganizationPermissionTokensAllowPermissionUpdates,
+		},
+		SystemPermissionTokens: TokenSecurityPolicy{
+			Enabled:                policies.SystemPermissionTokensEnabled,
+			MaxTTL:                 int64Value(policies.SystemPermissionTokensEnforceExpirationAfterMs),
+			AllowPermissionUpdates: policies.SystemPermissionTokensAllowPermissionUpdates,
+		},
+		SharedDashboardsEnabled:    resp.SharedDashboardsSecurityPolicies.SharedDashboardsEnabled,
+		SharedDashboardsIPFilterID: stringValue(resp.SharedDashboardsSecurityPolicies.EnforceIPFilterID),
+	}
+	if resp.Organization.DefaultRole != nil {
+		settings.DefaultRoleID = resp.Organization.DefaultRole.ID
+	}
+
+	return settings, nil
+}
+
+// UpdateSettings replaces the organization settings
+func (o *Organization) UpdateSettings(settings *OrganizationSettings) (*OrganizationSettings, error) {
+	err := o.client.Query(context.Background(), updateTokenSecurityPoliciesMutation, map[string]interface{}{
+		"Input": map[string]interface{}{
+			"personalUserTokensEnabled":                            settings.PersonalUserTokens.Enabled,
+			"personalUserTokensEnforceExpirationAfterMs":           optionalInt64(settings.PersonalUserTokens.MaxTTL),
+			"viewPermissionTokensEnabled":                          settings.ViewPermissionTokens.Enabled,
+			"viewPermissionTokensEnforceExpirationAfterMs":         optionalInt64(settings.ViewPermissionTokens.MaxTTL),
+			"viewPermissionTokensAllowPermissionUpdates":           settings.ViewPermissionTokens.AllowPermissionUpdates,
+			"organizationPermissionTokensEnabled":                  settings.OrganizationPermissionTokens.Enabled,
+			"organizationPermissionTokensEnforceExpirationAfterMs": optionalInt64(settings.OrganizationPermissionTokens.MaxTTL),
+			"organizationPermissionTokensAllowPermissionUpdates":   settings.OrganizationPermissionTokens.AllowPermissionUpdates,
+			"systemPermissionTokensEnabled":                        settings.SystemPermissionTokens.Enabled,
+			"systemPermissionTokensEnforceExpirationAfterMs":       optionalInt64(settings.SystemPermissionTokens.MaxTTL),
+			"systemPermissionTokensAllowPermissionUpdates":         settings.SystemPermissionTokens.AllowPermissionUpdates,
+		},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = o.client.Query(context.Background(), updateSharedDashboardsSecurityPoliciesMutation, map[string]interface{}{
+		"Enabled":    settings.SharedDashboardsEnabled,
+		"IPFilterID": optionalString(settings.SharedDashboardsIPFilterID),
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = o.client.Query(context.Background(), updateDefaultRoleMutation, map[string]interface{}{
+		"RoleID": optionalString(settings.DefaultRoleID),
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// int64Value returns the value of an optional number in a response, or 0 if it is null
+func int64Value(i *int64) int64 {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
+// optionalInt64 sends 0 as null, for optional arguments where null means no limit
+func optionalInt64(i int64) interface{} {
+	if i == 0 {
+		return nil
+	}
+	return i
+}
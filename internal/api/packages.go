@@ -0,0 +1,249 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// InstalledPackage represents a package installed in a Humio view or repository
+type InstalledPackage struct {
+	ID      string
+	Version string
+	// Assets holds the installed templates of the package by their path in the package, e.g.
+	// "parsers/accesslog.yaml". It is only filled in by Get.
+	Assets map[string]string
+}
+
+// PackageInstallResult represents the result of installing a package archive
+type PackageInstallResult struct {
+	InstallationErrors []string `json:"installationErrors"`
+	ParseErrors        []string `json:"parseErrors"`
+}
+
+// Packages provides operations for managing packages
+type Packages struct {
+	client *Client
+}
+
+const listInstalledPackagesQuery = `
+query ListInstalledPackages($SearchDomainName: String!) {
+  searchDomain(name: $SearchDomainName) {
+    installedPackages {
+      id
+      package {
+        scope
+        name
+        version
+      }
+    }
+  }
+}
+`
+
+const getInstalledPackagesWithAssetsQuery = `
+query GetInstalledPackagesWithAssets($SearchDomainName: String!) {
+  searchDomain(name: $SearchDomainName) {
+    installedPackages {
+      id
+      package {
+        scope
+        name
+        version
+        dashboardTemplates {
+          name
+          yamlTemplate
+        }
+        savedQueryTemplates {
+          name
+          yamlTemplate
+        }
+        parserTemplates {
+          name
+          yamlTemplate
+        }
+        alertTemplates {
+          name
+          yamlTemplate
+        }
+        actionTemplates {
+          name
+          yamlTemplate
+        }
+        scheduledSearchTemplates {
+          name
+          yamlTemplate
+        }
+        lookupFileTemplates {
+          name
+          content
+        }
+      }
+    }
+  }
+}
+`
+
+const uninstallPackageMutation = `
+mutation UninstallPackage($SearchDomainName: String!, $PackageID: UnversionedPackageSpecifier!) {
+  uninstallPackage(viewName: $SearchDomainName, packageId: $PackageID) {
+    __typename
+  }
+}
+`
+
+// listInstalledPackagesResponse represents the response from list installed packages query
+type listInstalledPackagesResponse struct {
+	SearchDomain struct {
+		InstalledPackages []struct {
+			ID      string `json:"id"`
+			Package struct {
+				Scope   string `json:"scope"`
+				Name    string `json:"name"`
+				Version string `json:"version"`
+			} `json:"package"`
+		} `json:"installedPackages"`
+	} `json:"searchDomain"`
+}
+
+// packageTemplate represents a YAML asset of an installed package in a response
+type packageTemplate struct {
+	Name         string `json:"name"`
+	YAMLTemplate string `json:"yamlTemplate"`
+}
+
+// getInstalledPackagesWithAssetsResponse represents the response from get installed packages with assets query
+type getInstalledPackagesWithAssetsResponse struct {
+	SearchDomain struct {
+		InstalledPackages []struct {
+			ID      string `json:"id"`
+			Package struct {
+				Scope                    string            `json:"scope"`
+				Name                     string            `json:"name"`
+				Version                  string            `json:"version"`
+				DashboardTemplates       []packageTemplate `json:"dashboardTemplates"`
+				SavedQueryTemplates      []packageTemplate `json:"savedQueryTemplates"`
+				ParserTemplates          []packageTemplate `json:"parserTemplates"`
+				AlertTemplates           []packageTemplate `json:"alertTemplates"`
+				ActionTemplates          []packageTemplate `json:"actionTemplates"`
+				ScheduledSearchTemplates []packageTemplate `json:"scheduledSearchTemplates"`
+				LookupFileTemplates      []struct {
+					Name    string `json:"name"`
+					Content string `json:"content"`
+				} `json:"lookupFileTemplates"`
+			} `json:"package"`
+		} `json:"installedPackages"`
+	} `json:"searchDomain"`
+}
+
+// List returns all packages installed in the given view or repository
+func (p *Packages) List(viewName string) ([]InstalledPackage, error) {
+	var resp listInstalledPackagesResponse
+	err := p.client.Query(context.Background(), listInstalledPackagesQuery, map[string]interface{}{
+		"SearchDomainName": viewName,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	packages := make([]InstalledPackage, len(resp.SearchDomain.InstalledPackages))
+	for i, pkg := range resp.SearchDomain.InstalledPackages {
+		packages[i] = InstalledPackage{
+			ID:      fmt.Sprintf("%s/%s", pkg.Package.Scope, pkg.Package.Name),
+			Version: pkg.Package.Version,
+		}
+	}
+
+	return packages, nil
+}
+
+// Get returns an installed package by its unversioned ID, e.g. "myscope/mypackage", along with its assets
+func (p *Packages) Get(viewName, packageID string) (*InstalledPackage, error) {
+	var resp getInstalledPackagesWithAssetsResponse
+	err := p.client.Query(context.Background(), getInstalledPackagesWithAssetsQuery, map[string]interface{}{
+		"SearchDomainName": viewName,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, installed := range resp.SearchDomain.InstalledPackages {
+		pkg := installed.Package
+		if fmt.Sprintf("%s/%s", pkg.Scope, pkg.Name) != packageID {
+			continue
+		}
+
+		assets := map[string]string{}
+		// The templates are keyed by the directories they are kept in within a package.
+		for dir, templates := range map[string][]packageTemplate{
+			"dashboards":        pkg.DashboardTemplates,
+			"queries":           pkg.SavedQueryTemplates,
+			"parsers":           pkg.ParserTemplates,
+			"alerts":            pkg.AlertTemplates,
+			"actions":           pkg.ActionTemplates,
+			"scheduledSearches": pkg.ScheduledSearchTemplates,
+		} {
+			for _, template := range templates {
+				assets[fmt.Sprintf("%s/%s.yaml", dir, template.Name)] = template.YAMLTemplate
+			}
+		}
+		for _, lookupFile := range pkg.LookupFileTemplates {
+			assets[fmt.Sprintf("lookupFiles/%s", lookupFile.Name)] = lookupFile.Content
+		}
+
+		return &InstalledPackage{
+			ID:      packageID,
+			Version: pkg.Version,
+			Assets:  assets,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("package not found: %s", packageID)
+}
+
+// Install installs or upgrades a package from a zip archive, overwriting any assets of an already installed version
+func (p *Packages) Install(viewName string, archive []byte) error {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "package.zip")
+	if err != nil {
+		return fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := part.Write(archive); err != nil {
+		return fmt.Errorf("failed to write package archive: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+
+	query := url.Values{}
+	query.Set("view", viewName)
+	query.Set("overwrite", "true")
+
+	var result PackageInstallResult
+	err = p.client.restRequest(context.Background(), http.MethodPost, "api/v1/packages/install", query, writer.FormDataContentType(), body, &result)
+	if err != nil {
+		return err
+	}
+
+	if len(result.InstallationErrors) > 0 || len(result.ParseErrors) > 0 {
+		errs := make([]string, 0, len(result.ParseErrors)+len(result.InstallationErrors))
+		errs = append(errs, result.ParseErrors...)
+		errs = append(errs, result.InstallationErrors...)
+		return fmt.Errorf("package could not be installed: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// Uninstall removes an installed package and its assets from the given view or repository
+func (p *Packages) Uninstall(viewName, packageID string) error {
+	return p.client.Query(context.Background(), uninstallPackageMutation, map[string]interface{}{
+		"SearchDomainName": viewName,
+		"PackageID":        packageID,
+	}, nil)
+}
@@ -2,9 +2,14 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 )
 
+// ErrParserNotFound is returned when getting a parser that does not exist
+var ErrParserNotFound = errors.New("parser not found")
+
 // ParserTestEvent represents a test event for a parser
 type ParserTestEvent struct {
 	RawString string
@@ -12,7 +17,22 @@ type ParserTestEvent struct {
 
 // ParserTestCase represents a test case for a parser
 type ParserTestCase struct {
-	Event ParserTestEvent
+	Event            ParserTestEvent
+	OutputAssertions []ParserTestCaseAssertions
+}
+
+// ParserTestCaseAssertions represents the assertions on one of the events a parser produces for a test case
+type ParserTestCaseAssertions struct {
+	OutputEventIndex int
+	// FieldsHaveValues maps field names to the values they are expected to have
+	FieldsHaveValues map[string]string
+	FieldsNotPresent []string
+}
+
+// ParserTestResult holds the events a parser produced for a single test case
+type ParserTestResult struct {
+	// OutputEvents holds the fields of each event; a parser may produce zero, one or more events per input
+	OutputEvents []map[string]string
 }
 
 // Parser represents a Humio parser
@@ -21,7 +41,11 @@ type Parser struct {
 	Name        string
 	Script      string
 	FieldsToTag []string
-	TestCases   []ParserTestCase
+	// FieldsToBeRemovedBeforeParsing are removed from the incoming events before the script runs
+	FieldsToBeRemovedBeforeParsing []string
+	TestCases                      []ParserTestCase
+	// IsBuiltIn reports whether the parser is one of the parsers shipped with Humio, which cannot be changed
+	IsBuiltIn bool
 }
 
 // Parsers provides operations for managing parsers
@@ -42,18 +66,30 @@ query ListParsers($RepositoryName: String!) {
 `
 
 const getParserQuery = `
-query GetParser($RepositoryName: String!, $ParserName: String!) {
+query GetParser($RepositoryName: String!, $ParserName: String, $ParserID: String) {
   repository(name: $RepositoryName) {
-    parser(name: $ParserName) {
+    parser(name: $ParserName, id: $ParserID) {
       id
       name
+      isBuiltIn
       script
       testCases {
         event {
           rawString
         }
+        outputAssertions {
+          outputEventIndex
+          assertions {
+            fieldsNotPresent
+            fieldsHaveValues {
+              fieldName
+              expectedValue
+            }
+          }
+        }
       }
       fieldsToTag
+      fieldsToBeRemovedBeforeParsing
     }
   }
 }
@@ -67,6 +103,7 @@ mutation CreateParser(
   $TestCases: [ParserTestCaseInput!]!
   $FieldsToTag: [String!]!
   $FieldsToBeRemovedBeforeParsing: [String!]!
+  $AllowOverwritingExistingParser: Boolean!
 ) {
   createParserV2(input: {
     repositoryName: $RepositoryName
@@ -75,6 +112,7 @@ mutation CreateParser(
     testCases: $TestCases
     fieldsToTag: $FieldsToTag
     fieldsToBeRemovedBeforeParsing: $FieldsToBeRemovedBeforeParsing
+    allowOverwritingExistingParser: $AllowOverwritingExistingParser
   }) {
     id
     name
@@ -107,6 +145,33 @@ mutation UpdateParser(
 }
 `
 
+const testParserMutation = `
+mutation TestParser(
+  $RepositoryName: RepoOrViewName!
+  $Script: String!
+  $TestCases: [ParserTestCaseInput!]!
+  $FieldsToTag: [String!]!
+  $FieldsToBeRemovedBeforeParsing: [String!]!
+) {
+  testParserV2(input: {
+    repositoryName: $RepositoryName
+    parserScript: $Script
+    testCases: $TestCases
+    fieldsToTag: $FieldsToTag
+    fieldsToBeRemovedBeforeParsing: $FieldsToBeRemovedBeforeParsing
+  }) {
+    results {
+      outputEvents {
+        fields {
+          fieldName
+          value
+        }
+      }
+    }
+  }
+}
+`
+
 const deleteParserMutation = `
 mutation DeleteParser($RepositoryName: RepoOrViewName!, $ParserID: String!) {
   deleteParser(input: {
@@ -133,19 +198,34 @@ type listParsersResponse struct {
 type getParserResponse struct {
 	Repository struct {
 		Parser *struct {
-			ID        string `json:"id"`
-			Name      string `json:"name"`
-			Script    string `json:"script"`
-			TestCases []struct {
-				Event struct {
-					RawString string `json:"rawString"`
-				} `json:"event"`
-			} `json:"testCases"`
-			FieldsToTag []string `json:"fieldsToTag"`
+			ID                             string                   `json:"id"`
+			Name                           string                   `json:"name"`
+			IsBuiltIn                      bool                     `json:"isBuiltIn"`
+			Script                         string                   `json:"script"`
+			TestCases                      []parserTestCaseResponse `json:"testCases"`
+			FieldsToTag                    []string                 `json:"fieldsToTag"`
+			FieldsToBeRemovedBeforeParsing []string                 `json:"fieldsToBeRemovedBeforeParsing"`
 		} `json:"parser"`
 	} `json:"repository"`
 }
 
+// parserTestCaseResponse represents a parser test case in a response
+type parserTestCaseResponse struct {
+	Event struct {
+		RawString string `json:"rawString"`
+	} `json:"event"`
+	OutputAssertions []struct {
+		OutputEventIndex int `json:"outputEventIndex"`
+		Assertions       struct {
+			FieldsNotPresent []string `json:"fieldsNotPresent"`
+			FieldsHaveValues []struct {
+				FieldName     string `json:"fieldName"`
+				ExpectedValue string `json:"expectedValue"`
+			} `json:"fieldsHaveValues"`
+		} `json:"assertions"`
+	} `json:"outputAssertions"`
+}
+
 // createParserResponse represents the response from create parser mutation
 type createParserResponse struct {
 	CreateParserV2 struct {
@@ -162,8 +242,39 @@ type updateParserResponse struct {
 	} `json:"updateParserV2"`
 }
 
-// List returns all parsers for the given repository
+// testParserResponse represents the response from test parser mutation
+type testParserResponse struct {
+	TestParserV2 struct {
+		Results []struct {
+			OutputEvents []struct {
+				Fields []struct {
+					FieldName string `json:"fieldName"`
+					Value     string `json:"value"`
+				} `json:"fields"`
+			} `json:"outputEvents"`
+		} `json:"results"`
+	} `json:"testParserV2"`
+}
+
+// List returns all parsers for the given repository, leaving out the built-in parsers
 func (p *Parsers) List(repository string) ([]Parser, error) {
+	allParsers, err := p.ListAll(repository)
+	if err != nil {
+		return nil, err
+	}
+
+	parsers := make([]Parser, 0, len(allParsers))
+	for _, parser := range allParsers {
+		if !parser.IsBuiltIn {
+			parsers = append(parsers, parser)
+		}
+	}
+
+	return parsers, nil
+}
+
+// ListAll returns all parsers for the given repository, including the built-in parsers
+func (p *Parsers) ListAll(repository string) ([]Parser, error) {
 	var resp listParsersResponse
 	err := p.client.Query(context.Background(), listParsersQuery, map[string]interface{}{
 		"RepositoryName": repository,
@@ -172,13 +283,12 @@ func (p *Parsers) List(repository string) ([]Parser, error) {
 		return nil, err
 	}
 
-	parsers := make([]Parser, 0, len(resp.Repository.Parsers))
-	for _, parser := range resp.Repository.Parsers {
-		if !parser.IsBuiltIn {
-			parsers = append(parsers, Parser{
-				ID:   parser.ID,
-				Name: parser.Name,
-			})
+	parsers := make([]Parser, len(resp.Repository.Parsers))
+	for i, parser := range resp.Repository.Parsers {
+		parsers[i] = Parser{
+			ID:        parser.ID,
+			Name:      parser.Name,
+			IsBuiltIn: parser.IsBuiltIn,
 		}
 	}
 
@@ -187,49 +297,45 @@ func (p *Parsers) List(repository string) ([]Parser, error) {
 
 // Get returns a parser by name
 func (p *Parsers) Get(repository, name string) (*Parser, error) {
-	var resp getParserResponse
-	err := p.client.Query(context.Background(), getParserQuery, map[string]interface{}{
+	return p.get(name, map[string]interface{}{
 		"RepositoryName": repository,
 		"ParserName":     name,
-	}, &resp)
+	})
+}
+
+// GetByID returns a parser by ID, which unlike the name does not change when the parser is renamed
+func (p *Parsers) GetByID(repository, id string) (*Parser, error) {
+	return p.get(id, map[string]interface{}{
+		"RepositoryName": repository,
+		"ParserID":       id,
+	})
+}
+
+func (p *Parsers) get(identifier string, variables map[string]interface{}) (*Parser, error) {
+	var resp getParserResponse
+	err := p.client.Query(context.Background(), getParserQuery, variables, &resp)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.Repository.Parser == nil {
-		return nil, fmt.Errorf("parser not found: %s", name)
+		return nil, fmt.Errorf("%w: %s", ErrParserNotFound, identifier)
 	}
 
 	rawParser := resp.Repository.Parser
-	testCases := make([]ParserTestCase, len(rawParser.TestCases))
-	for i, tc := range rawParser.TestCases {
-		testCases[i] = ParserTestCase{
-			Event: ParserTestEvent{
-				RawString: tc.Event.RawString,
-			},
-		}
-	}
-
 	return &Parser{
-		ID:          rawParser.ID,
-		Name:        rawParser.Name,
-		Script:      rawParser.Script,
-		FieldsToTag: rawParser.FieldsToTag,
-		TestCases:   testCases,
+		ID:                             rawParser.ID,
+		Name:                           rawParser.Name,
+		Script:                         rawParser.Script,
+		FieldsToTag:                    rawParser.FieldsToTag,
+		FieldsToBeRemovedBeforeParsing: rawParser.FieldsToBeRemovedBeforeParsing,
+		TestCases:                      parserTestCasesFromResponse(rawParser.TestCases),
+		IsBuiltIn:                      rawParser.IsBuiltIn,
 	}, nil
 }
 
-// Add creates a new parser or updates an existing one
+// Add creates a new parser. With force, an existing parser of the same name is overwritten instead of failing.
 func (p *Parsers) Add(repository string, parser *Parser, force bool) (*Parser, error) {
-	testCases := make([]map[string]interface{}, len(parser.TestCases))
-	for i, tc := range parser.TestCases {
-		testCases[i] = map[string]interface{}{
-			"event": map[string]interface{}{
-				"rawString": tc.Event.RawString,
-			},
-		}
-	}
-
 	fieldsToTag := parser.FieldsToTag
 	if fieldsToTag == nil {
 		fieldsToTag = []string{}
@@ -239,9 +345,10 @@ func (p *Parsers) Add(repository string, parser *Parser, force bool) (*Parser, e
 		"RepositoryName":                 repository,
 		"Name":                           parser.Name,
 		"Script":                         parser.Script,
-		"TestCases":                      testCases,
+		"TestCases":                      parserTestCasesInput(parser.TestCases),
 		"FieldsToTag":                    fieldsToTag,
-		"FieldsToBeRemovedBeforeParsing": []string{},
+		"FieldsToBeRemovedBeforeParsing": fieldsToBeRemovedBeforeParsing(parser),
+		"AllowOverwritingExistingParser": force,
 	}
 
 	var resp createParserResponse
@@ -256,15 +363,6 @@ func (p *Parsers) Add(repository string, parser *Parser, force bool) (*Parser, e
 
 // Update updates an existing parser
 func (p *Parsers) Update(repository string, parser *Parser) (*Parser, error) {
-	testCases := make([]map[string]interface{}, len(parser.TestCases))
-	for i, tc := range parser.TestCases {
-		testCases[i] = map[string]interface{}{
-			"event": map[string]interface{}{
-				"rawString": tc.Event.RawString,
-			},
-		}
-	}
-
 	fieldsToTag := parser.FieldsToTag
 	if fieldsToTag == nil {
 		fieldsToTag = []string{}
@@ -277,9 +375,9 @@ func (p *Parsers) Update(repository string, parser *Parser) (*Parser, error) {
 		"Script": map[string]interface{}{
 			"script": parser.Script,
 		},
-		"TestCases":                      testCases,
+		"TestCases":                      parserTestCasesInput(parser.TestCases),
 		"FieldsToTag":                    fieldsToTag,
-		"FieldsToBeRemovedBeforeParsing": []string{},
+		"FieldsToBeRemovedBeforeParsing": fieldsToBeRemovedBeforeParsing(parser),
 	}
 
 	var resp updateParserResponse
@@ -300,8 +398,128 @@ func (p *Parsers) Delete(repository, name string) error {
 		return err
 	}
 
+	return p.DeleteByID(repository, parser.ID)
+}
+
+// DeleteByID deletes a parser by ID
+func (p *Parsers) DeleteByID(repository, id string) error {
 	return p.client.Query(context.Background(), deleteParserMutation, map[string]interface{}{
 		"RepositoryName": repository,
-		"ParserID":       parser.ID,
+		"ParserID":       id,
 	}, nil)
 }
+
+// Test runs the test cases of the given parser against its script on the server without saving anything. The results
+// are in the same order as the test cases.
+func (p *Parsers) Test(repository string, parser *Parser) ([]ParserTestResult, error) {
+	fieldsToTag := parser.FieldsToTag
+	if fieldsToTag == nil {
+		fieldsToTag = []string{}
+	}
+
+	var resp testParserResponse
+	err := p.client.Query(context.Background(), testParserMutation, map[string]interface{}{
+		"RepositoryName":                 repository,
+		"Script":                         parser.Script,
+		"TestCases":                      parserTestCasesInput(parser.TestCases),
+		"FieldsToTag":                    fieldsToTag,
+		"FieldsToBeRemovedBeforeParsing": fieldsToBeRemovedBeforeParsing(parser),
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.TestParserV2.Results) != len(parser.TestCases) {
+		return nil, fmt.Errorf("expected %d test results, got %d", len(parser.TestCases), len(resp.TestParserV2.Results))
+	}
+
+	results := make([]ParserTestResult, len(resp.TestParserV2.Results))
+	for i, result := range resp.TestParserV2.Results {
+		outputEvents := make([]map[string]string, len(result.OutputEvents))
+		for j, event := range result.OutputEvents {
+			fields := make(map[string]string, len(event.Fields))
+			for _, field := range event.Fields {
+				fields[field.FieldName] = field.Value
+			}
+			outputEvents[j] = fields
+		}
+		results[i] = ParserTestResult{OutputEvents: outputEvents}
+	}
+
+	return results, nil
+}
+
+// parserTestCasesInput converts test cases into the ParserTestCaseInput shape expected by the V2 parser mutations
+func parserTestCasesInput(testCases []ParserTestCase) []map[string]interface{} {
+	input := make([]map[string]interface{}, len(testCases))
+	for i, tc := range testCases {
+		outputAssertions := make([]map[string]interface{}, len(tc.OutputAssertions))
+		for j, assertions := range tc.OutputAssertions {
+			fieldNames := make([]string, 0, len(assertions.FieldsHaveValues))
+			for fieldName := range assertions.FieldsHaveValues {
+				fieldNames = append(fieldNames, fieldName)
+			}
+			sort.Strings(fieldNames)
+			fieldsHaveValues := make([]map[string]interface{}, len(fieldNames))
+			for k, fieldName := range fieldNames {
+				fieldsHaveValues[k] = map[string]interface{}{
+					"fieldName":     fieldName,
+					"expectedValue": assertions.FieldsHaveValues[fieldName],
+				}
+			}
+			fieldsNotPresent := assertions.FieldsNotPresent
+			if fieldsNotPresent == nil {
+				fieldsNotPresent = []string{}
+			}
+			outputAssertions[j] = map[string]interface{}{
+				"outputEventIndex": assertions.OutputEventIndex,
+				"assertions": map[string]interface{}{
+					"fieldsNotPresent": fieldsNotPresent,
+					"fieldsHaveValues": fieldsHaveValues,
+				},
+			}
+		}
+		input[i] = map[string]interface{}{
+			"event": map[string]interface{}{
+				"rawString": tc.Event.RawString,
+			},
+			"outputAssertions": outputAssertions,
+		}
+	}
+	return input
+}
+
+// parserTestCasesFromResponse converts test cases read from the server, leaving out empty assertions
+func parserTestCasesFromResponse(rawTestCases []parserTestCaseResponse) []ParserTestCase {
+	testCases := make([]ParserTestCase, len(rawTestCases))
+	for i, tc := range rawTestCases {
+		testCases[i] = ParserTestCase{
+			Event: ParserTestEvent{
+				RawString: tc.Event.RawString,
+			},
+		}
+		for _, rawAssertions := range tc.OutputAssertions {
+			assertions := ParserTestCaseAssertions{
+				OutputEventIndex: rawAssertions.OutputEventIndex,
+			}
+			if len(rawAssertions.Assertions.FieldsHaveValues) > 0 {
+				assertions.FieldsHaveValues = make(map[string]string, len(rawAssertions.Assertions.FieldsHaveValues))
+				for _, field := range rawAssertions.Assertions.FieldsHaveValues {
+					assertions.FieldsHaveValues[field.FieldName] = field.ExpectedValue
+				}
+			}
+			if len(rawAssertions.Assertions.FieldsNotPresent) > 0 {
+				assertions.FieldsNotPresent = rawAssertions.Assertions.FieldsNotPresent
+			}
+			testCases[i].OutputAssertions = append(testCases[i].OutputAssertions, assertions)
+		}
+	}
+	return testCases
+}
+
+func fieldsToBeRemovedBeforeParsing(parser *Parser) []string {
+	if parser.FieldsToBeRemovedBeforeParsing == nil {
+		return []string{}
+	}
+	return parser.FieldsToBeRemovedBeforeParsing
+}
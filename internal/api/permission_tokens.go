@@ -0,0 +1,370 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Permission token type constants, matching the TokenType values of the GraphQL API
+const (
+	PermissionTokenTypeOrganization = "OrganizationPermissionToken"
+	PermissionTokenTypeView         = "ViewPermissionToken"
+	PermissionTokenTypeSystem       = "SystemPermissionToken"
+)
+
+// PermissionToken represents a Humio API token scoped to a set of organization, view or system permissions
+type PermissionToken struct {
+	ID          string
+	Name        string
+	Type        string
+	Permissions []string
+	// Views holds the names of the views a view permission token grants access to
+	Views []string
+	// ExpireAt is the expiry time in milliseconds since the epoch, or 0 if the token never expires
+	ExpireAt   int64
+	IPFilterID string
+}
+
+// PermissionTokens provides operations for managing permission tokens
+type PermissionTokens struct {
+	client *Client
+}
+
+const listPermissionTokensQuery = `
+query ListPermissionTokens($TypeFilter: [TokenType!]) {
+  tokensPage(typeFilter: $TypeFilter, limit: 10000) {
+    page {
+      id
+      name
+      expireAt
+      ipFilterV2 {
+        id
+      }
+      ... on OrganizationPermissionsToken {
+        permissions
+      }
+      ... on ViewPermissionsToken {
+        permissions
+        views {
+          name
+        }
+      }
+      ... on SystemPermissionsToken {
+        permissions
+      }
+    }
+  }
+}
+`
+
+const getSearchDomainIDQuery = `
+query GetSearchDomainID($SearchDomainName: String!) {
+  searchDomain(name: $SearchDomainName) {
+    id
+  }
+}
+`
+
+const createOrganizationPermissionTokenMutation = `
+mutation CreateOrganizationPermissionToken(
+  $Name: String!
+  $ExpireAt: Long
+  $IPFilterID: String
+  $Permissions: [OrganizationPermission!]!
+) {
+  createOrganizationPermissionsToken(input: {
+    name: $Name
+    expireAt: $ExpireAt
+    ipFilterId: $IPFilterID
+    permissions: $Permissions
+  })
+}
+`
+
+const createViewPermissionTokenMutation = `
+mutation CreateViewPermissionToken(
+  $Name: String!
+  $ExpireAt: Long
+  $IPFilterID: String
+  $ViewIDs: [String!]!
+  $Permissions: [Permission!]!
+) {
+  createViewPermissionsToken(input: {
+    name: $Name
+    expireAt: $ExpireAt
+    ipFilterId: $IPFilterID
+    viewIds: $ViewIDs
+    viewPermissions: $Permissions
+  })
+}
+`
+
+const createSystemPermissionTokenMutation = `
+mutation CreateSystemPermissionToken(
+  $Name: String!
+  $ExpireAt: Long
+  $IPFilterID: String
+  $Permissions: [SystemPermission!]!
+) {
+  createSystemPermissionsToken(input: {
+    name: $Name
+    expireAt: $ExpireAt
+    ipFilterId: $IPFilterID
+    permissions: $Permissions
+  })
+}
+`
+
+const updateOrganizationPermissionTokenMutation = `
+mutation UpdateOrganizationPermissionToken($ID: String!, $Permissions: [OrganizationPermission!]!) {
+  updateOrganizationPermissionsTokenPermissions(input: {
+    id: $ID
+    permissions: $Permissions
+  })
+}
+`
+
+const updateViewPermissionTokenMutation = `
+mutation UpdateViewPermissionToken($ID: String!, $Permissions: [Permission!]!) {
+  updateViewPermissionsTokenPermissions(input: {
+    id: $ID
+    permissions: $Permissions
+  })
+}
+`
+
+const updateSystemPermissionTokenMutation = `
+mutation UpdateSystemPermissionToken($ID: String!, $Permissions: [SystemPermission!]!) {
+  updateSystemPermissionsTokenPermissions(input: {
+    id: $ID
+    permissions: $Permissions
+  })
+}
+`
+
+const getPermissionEnumValuesQuery = `
+query GetPermissionEnumValues($TypeName: String!) {
+  __type(name: $TypeName) {
+    enumValues {
+      name
+    }
+  }
+}
+`
+
+const deleteTokenMutation = `
+mutation DeleteToken($ID: String!) {
+  deleteToken(input: {
+    id: $ID
+  })
+}
+`
+
+// listPermissionTokensResponse represents the response from list permission tokens query
+type listPermissionTokensResponse struct {
+	TokensPage struct {
+		Page []struct {
+			ID         string `json:"id"`
+			Name       string `json:"name"`
+			ExpireAt   *int64 `json:"expireAt"`
+			IPFilterV2 *struct {
+				ID string `json:"id"`
+			} `json:"ipFilterV2"`
+			Permissions []string `json:"permissions"`
+			Views       []struct {
+				Name string `json:"name"`
+			} `json:"views"`
+		} `json:"page"`
+	} `json:"tokensPage"`
+}
+
+// getSearchDomainIDResponse represents the response from get search domain ID query
+type getSearchDomainIDResponse struct {
+	SearchDomain struct {
+		ID string `json:"id"`
+	} `json:"searchDomain"`
+}
+
+// List returns all permission tokens of the given type
+func (p *PermissionTokens) List(tokenType string) ([]PermissionToken, error) {
+	var resp listPermissionTokensResponse
+	err := p.client.Query(context.Background(), listPermissionTokensQuery, map[string]interface{}{
+		"TypeFilter": []string{tokenType},
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]PermissionToken, len(resp.TokensPage.Page))
+	for i, token := range resp.TokensPage.Page {
+		var expireAt int64
+		if token.ExpireAt != nil {
+			expireAt = *token.ExpireAt
+		}
+		ipFilterID := ""
+		if token.IPFilterV2 != nil {
+			ipFilterID = token.IPFilterV2.ID
+		}
+		views := make([]string, len(token.Views))
+		for j, view := range token.Views {
+			views[j] = view.Name
+		}
+		tokens[i] = PermissionToken{
+			ID:          token.ID,
+			Name:        token.Name,
+			Type:        tokenType,
+			Permissions: token.Permissions,
+			Views:       views,
+			ExpireAt:    expireAt,
+			IPFilterID:  ipFilterID,
+		}
+	}
+
+	return tokens, nil
+}
+
+// Get returns a permission token of the given type by ID
+func (p *PermissionTokens) Get(tokenType, id string) (*PermissionToken, error) {
+	tokens, err := p.List(tokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, token := range tokens {
+		if token.ID == id {
+			return &token, nil
+		}
+	}
+
+	return nil, fmt.Errorf("permission token not found: %s", id)
+}
+
+// Add creates a new permission token and returns it along with its secret. The secret can only be obtained at creation.
+func (p *PermissionTokens) Add(token *PermissionToken) (*PermissionToken, string, error) {
+	variables := map[string]interface{}{
+		"Name":        token.Name,
+		"Permissions": token.Permissions,
+	}
+	if token.ExpireAt > 0 {
+		variables["ExpireAt"] = token.ExpireAt
+	}
+	if token.IPFilterID != "" {
+		variables["IPFilterID"] = token.IPFilterID
+	}
+
+	var mutation, field string
+	switch token.Type {
+	case PermissionTokenTypeOrganization:
+		mutation, field = createOrganizationPermissionTokenMutation, "createOrganizationPermissionsToken"
+	case PermissionTokenTypeView:
+		viewIDs := make([]string, len(token.Views))
+		for i, view := range token.Views {
+			id, err := p.searchDomainID(view)
+			if err != nil {
+				return nil, "", err
+			}
+			viewIDs[i] = id
+		}
+		variables["ViewIDs"] = viewIDs
+		mutation, field = createViewPermissionTokenMutation, "createViewPermissionsToken"
+	case PermissionTokenTypeSystem:
+		mutation, field = createSystemPermissionTokenMutation, "createSystemPermissionsToken"
+	default:
+		return nil, "", fmt.Errorf("unsupported permission token type: %s", token.Type)
+	}
+
+	var resp map[string]string
+	err := p.client.Query(context.Background(), mutation, variables, &resp)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// The token is returned in the form "id~secret", and the whole string is what is used for authentication
+	secret := resp[field]
+	id, _, found := strings.Cut(secret, "~")
+	if !found {
+		return nil, "", fmt.Errorf("unexpected format of created permission token")
+	}
+	token.ID = id
+	return token, secret, nil
+}
+
+// getPermissionEnumValuesResponse represents the response from get permission enum values query
+type getPermissionEnumValuesResponse struct {
+	Type *struct {
+		EnumValues []struct {
+			Name string `json:"name"`
+		} `json:"enumValues"`
+	} `json:"__type"`
+}
+
+// ValidPermissions returns the permissions the server accepts for the given type of permission token
+func (p *PermissionTokens) ValidPermissions(tokenType string) ([]string, error) {
+	var typeName string
+	switch tokenType {
+	case PermissionTokenTypeOrganization:
+		typeName = "OrganizationPermission"
+	case PermissionTokenTypeView:
+		typeName = "Permission"
+	case PermissionTokenTypeSystem:
+		typeName = "SystemPermission"
+	default:
+		return nil, fmt.Errorf("unsupported permission token type: %s", tokenType)
+	}
+
+	var resp getPermissionEnumValuesResponse
+	err := p.client.Query(context.Background(), getPermissionEnumValuesQuery, map[string]interface{}{
+		"TypeName": typeName,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Type == nil {
+		return nil, fmt.Errorf("permission type not found: %s", typeName)
+	}
+
+	permissions := make([]string, len(resp.Type.EnumValues))
+	for i, value := range resp.Type.EnumValues {
+		permissions[i] = value.Name
+	}
+	return permissions, nil
+}
+
+// UpdatePermissions replaces the permissions of an existing permission token
+func (p *PermissionTokens) UpdatePermissions(token *PermissionToken) error {
+	var mutation string
+	switch token.Type {
+	case PermissionTokenTypeOrganization:
+		mutation = updateOrganizationPermissionTokenMutation
+	case PermissionTokenTypeView:
+		mutation = updateViewPermissionTokenMutation
+	case PermissionTokenTypeSystem:
+		mutation = updateSystemPermissionTokenMutation
+	default:
+		return fmt.Errorf("unsupported permission token type: %s", token.Type)
+	}
+
+	return p.client.Query(context.Background(), mutation, map[string]interface{}{
+		"ID":          token.ID,
+		"Permissions": token.Permissions,
+	}, nil)
+}
+
+// Delete deletes a permission token by ID
+func (p *PermissionTokens) Delete(id string) error {
+	return p.client.Query(context.Background(), deleteTokenMutation, map[string]interface{}{
+		"ID": id,
+	}, nil)
+}
+
+func (p *PermissionTokens) searchDomainID(name string) (string, error) {
+	var resp getSearchDomainIDResponse
+	err := p.client.Query(context.Background(), getSearchDomainIDQuery, map[string]interface{}{
+		"SearchDomainName": name,
+	}, &resp)
+	if err != nil {
+		return "", fmt.Errorf("could not look up view %s: %w", name, err)
+	}
+	return resp.SearchDomain.ID, nil
+}
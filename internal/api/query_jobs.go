@@ -0,0 +1,165 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultQueryJobPollInterval is used when the server does not suggest when to poll again
+const defaultQueryJobPollInterval = time.Second
+
+// queryJobDeleteTimeout bounds how long deleting a finished or abandoned query job may take
+const queryJobDeleteTimeout = 10 * time.Second
+
+// QueryJobInput describes a static search to run against a repository or view
+type QueryJobInput struct {
+	QueryString string
+	// Start and End are either relative times such as "24h" or absolute times in milliseconds since the epoch. An
+	// empty End means now.
+	Start string
+	End   string
+}
+
+// QueryJobResult holds the events and metadata of a completed query job
+type QueryJobResult struct {
+	// Events holds the fields of each event; values that are not strings on the server are JSON encoded
+	Events          []map[string]string
+	EventCount      int64
+	ProcessedEvents int64
+	ProcessedBytes  int64
+	FieldOrder      []string
+	QueryStart      int64
+	QueryEnd        int64
+}
+
+// QueryJobs provides operations for running searches through the query job REST API
+type QueryJobs struct {
+	client *Client
+}
+
+// queryJobRequest represents the body of a query job submission. Start and End are strings for relative times and
+// numbers for absolute times, as the server expects.
+type queryJobRequest struct {
+	QueryString string      `json:"queryString"`
+	Start       interface{} `json:"start,omitempty"`
+	End         interface{} `json:"end,omitempty"`
+	IsLive      bool        `json:"isLive"`
+}
+
+// queryJobCreateResponse represents the response from submitting a query job
+type queryJobCreateResponse struct {
+	ID string `json:"id"`
+}
+
+// queryJobPollResponse represents the response from polling a query job
+type queryJobPollResponse struct {
+	Done      bool                     `json:"done"`
+	Cancelled bool                     `json:"cancelled"`
+	Events    []map[string]interface{} `json:"events"`
+	MetaData  struct {
+		EventCount      int64    `json:"eventCount"`
+		ProcessedEvents int64    `json:"processedEvents"`
+		ProcessedBytes  int64    `json:"processedBytes"`
+		FieldOrder      []string `json:"fieldOrder"`
+		QueryStart      int64    `json:"queryStart"`
+		QueryEnd        int64    `json:"queryEnd"`
+		PollAfter       int      `json:"pollAfter"`
+	} `json:"metaData"`
+}
+
+// Run submits a query job to the given repository or view and polls it until it is done or ctx expires. The job is
+// deleted on the server afterwards, whether it completed or not.
+func (q *QueryJobs) Run(ctx context.Context, searchDomain string, input QueryJobInput) (*QueryJobResult, error) {
+	body, err := json.Marshal(queryJobRequest{
+		QueryString: input.QueryString,
+		Start:       queryJobTime(input.Start),
+		End:         queryJobTime(input.End),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal query job: %w", err)
+	}
+
+	path := fmt.Sprintf("api/v1/repositories/%s/queryjobs", searchDomain)
+	var created queryJobCreateResponse
+	err = q.client.restRequest(ctx, http.MethodPost, path, nil, "application/json", bytes.NewReader(body), &created)
+	if err != nil {
+		return nil, err
+	}
+
+	jobPath := fmt.Sprintf("%s/%s", path, created.ID)
+	// The job may outlive ctx, so it is deleted with a context of its own.
+	defer func() {
+		deleteCtx, cancel := context.WithTimeout(context.Background(), queryJobDeleteTimeout)
+		defer cancel()
+		_ = q.client.restRequest(deleteCtx, http.MethodDelete, jobPath, nil, "", nil, nil)
+	}()
+
+	for {
+		var resp queryJobPollResponse
+		err := q.client.restRequest(ctx, http.MethodGet, jobPath, nil, "", nil, &resp)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Cancelled {
+			return nil, fmt.Errorf("query job %s was cancelled by the server", created.ID)
+		}
+		if resp.Done {
+			return queryJobResultFromResponse(resp)
+		}
+
+		pollAfter := defaultQueryJobPollInterval
+		if resp.MetaData.PollAfter > 0 {
+			pollAfter = time.Duration(resp.MetaData.PollAfter) * time.Millisecond
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("query job %s did not complete in time: %w", created.ID, ctx.Err())
+		case <-time.After(pollAfter):
+		}
+	}
+}
+
+// queryJobTime returns an absolute time in milliseconds since the epoch as a number, and a relative time as it is. An
+// empty time is returned as nil, so it is left out of the request.
+func queryJobTime(t string) interface{} {
+	if t == "" {
+		return nil
+	}
+	if ms, err := strconv.ParseInt(t, 10, 64); err == nil {
+		return ms
+	}
+	return t
+}
+
+func queryJobResultFromResponse(resp queryJobPollResponse) (*QueryJobResult, error) {
+	result := &QueryJobResult{
+		Events:          make([]map[string]string, len(resp.Events)),
+		EventCount:      resp.MetaData.EventCount,
+		ProcessedEvents: resp.MetaData.ProcessedEvents,
+		ProcessedBytes:  resp.MetaData.ProcessedBytes,
+		FieldOrder:      resp.MetaData.FieldOrder,
+		QueryStart:      resp.MetaData.QueryStart,
+		QueryEnd:        resp.MetaData.QueryEnd,
+	}
+	for i, event := range resp.Events {
+		fields := make(map[string]string, len(event))
+		for name, value := range event {
+			if s, ok := value.(string); ok {
+				fields[name] = s
+				continue
+			}
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode field %s: %w", name, err)
+			}
+			fields[name] = string(encoded)
+		}
+		result.Events[i] = fields
+	}
+	return result, nil
+}
@@ -0,0 +1,248 @@
+package api
+
+import (
+	"context"
+	"sort"
+)
+
+// Query quota interval constants
+const (
+	QueryQuotaIntervalPerDay        = "PerDay"
+	QueryQuotaIntervalPerHour       = "PerHour"
+	QueryQuotaIntervalPerTenMinutes = "PerTenMinutes"
+	QueryQuotaIntervalPerMinute     = "PerMinute"
+)
+
+const (
+	queryQuotaMeasurementStaticCost = "StaticCost"
+	queryQuotaMeasurementLiveCost   = "LiveCost"
+	queryQuotaValueLimited          = "Limited"
+	queryQuotaValueUnlimited        = "Unlimited"
+)
+
+// QueryQuota represents the query quota of a user, or the default quota for users without one of their own
+type QueryQuota struct {
+	// Username is empty for the default quota
+	Username string
+	// Limits holds the limits of each interval the quota has settings for
+	Limits map[string]QueryQuotaLimits
+	// IsDefault reports whether the limits are those of the default quota, which is the case for users without a quota
+	// of their own
+	IsDefault bool
+}
+
+// QueryQuotaLimits represents the limits of a query quota within a single interval
+type QueryQuotaLimits struct {
+	// MaxQueryCost and MaxLiveQueryCost are 0 when unlimited
+	MaxQueryCost     int
+	MaxLiveQueryCost int
+}
+
+// QueryQuotas provides operations for managing query quotas
+type QueryQuotas struct {
+	client *Client
+}
+
+const getDefaultQueryQuotaQuery = `
+query GetDefaultQueryQuota {
+  queryQuotaDefaultSettings {
+    interval
+    measurementKind
+    value
+    valueKind
+  }
+}
+`
+
+const getUserQueryQuotaQuery = `
+query GetUserQueryQuota($Username: String!) {
+  queryQuotaUserSettings(username: $Username) {
+    interval
+    measurementKind
+    value
+    valueKind
+  }
+}
+`
+
+const setDefaultQueryQuotaMutation = `
+mutation SetDefaultQueryQuota($Settings: [QueryQuotaIntervalSettingInput!]!) {
+  setQueryQuotaDefaultSettings(input: {
+    settings: $Settings
+  }) {
+    __typename
+  }
+}
+`
+
+const setUserQueryQuotaMutation = `
+mutation SetUserQueryQuota($Username: String!, $Settings: [QueryQuotaIntervalSettingInput!]!) {
+  setQueryQuotaUserSettings(input: {
+    username: $Username
+    settings: $Settings
+  }) {
+    __typename
+  }
+}
+`
+
+const removeUserQueryQuotaMutation = `
+mutation RemoveUserQueryQuota($Username: String!) {
+  removeQueryQuotaUserSettings(username: $Username)
+}
+`
+
+// queryQuotaSetting represents a single interval setting in the query quota responses
+type queryQuotaSetting struct {
+	Interval        string `json:"interval"`
+	MeasurementKind string `json:"measurementKind"`
+	Value           *int   `json:"value"`
+	ValueKind       string `json:"valueKind"`
+}
+
+// getDefaultQueryQuotaResponse represents the response from get default query quota query
+type getDefaultQueryQuotaResponse struct {
+	QueryQuotaDefaultSettings []queryQuotaSetting `json:"queryQuotaDefaultSettings"`
+}
+
+// getUserQueryQuotaResponse represents the response from get user query quota query
+type getUserQueryQuotaResponse struct {
+	QueryQuotaUserSettings []queryQuotaSetting `json:"queryQuotaUserSettings"`
+}
+
+// Get returns the effective query quota of the given user, or the default quota if username is empty. A user without
+// a quota of their own gets the default quota.
+func (q *QueryQuotas) Get(username string) (*QueryQuota, error) {
+	if username != "" {
+		limits, err := q.getLimits(username)
+		if err != nil {
+			return nil, err
+		}
+		if len(limits) > 0 {
+			return &QueryQuota{Username: username, Limits: limits}, nil
+		}
+	}
+
+	limits, err := q.getLimits("")
+	if err != nil {
+		return nil, err
+	}
+	return &QueryQuota{Username: username, Limits: limits, IsDefault: true}, nil
+}
+
+// Set sets the limits of the given intervals on the query quota of the user, or the default quota if the username is
+// empty. The limits of other intervals are left as they are.
+func (q *QueryQuotas) Set(quota *QueryQuota) (*QueryQuota, error) {
+	limits, err := q.getLimits(quota.Username)
+	if err != nil {
+		return nil, err
+	}
+	for interval, intervalLimits := range quota.Limits {
+		limits[interval] = intervalLimits
+	}
+
+	if err := q.setLimits(quota.Username, limits); err != nil {
+		return nil, err
+	}
+	return &QueryQuota{Username: quota.Username, Limits: limits}, nil
+}
+
+// Remove removes the limits of the given interval from the query quota of the user, or the default quota if the
+// username is empty. A user left without any limits has the default quota apply again.
+func (q *QueryQuotas) Remove(username, interval string) error {
+	limits, err := q.getLimits(username)
+	if err != nil {
+		return err
+	}
+	delete(limits, interval)
+
+	if username != "" && len(limits) == 0 {
+		return q.client.Query(context.Background(), removeUserQueryQuotaMutation, map[string]interface{}{
+			"Username": username,
+		}, nil)
+	}
+	return q.setLimits(username, limits)
+}
+
+// getLimits returns the limits the user has settings for, or those of the default quota if username is empty
+func (q *QueryQuotas) getLimits(username string) (map[string]QueryQuotaLimits, error) {
+	var settings []queryQuotaSetting
+	if username == "" {
+		var resp getDefaultQueryQuotaResponse
+		err := q.client.Query(context.Background(), getDefaultQueryQuotaQuery, nil, &resp)
+		if err != nil {
+			return nil, err
+		}
+		settings = resp.QueryQuotaDefaultSettings
+	} else {
+		var resp getUserQueryQuotaResponse
+		err := q.client.Query(context.Background(), getUserQueryQuotaQuery, map[string]interface{}{
+			"Username": username,
+		}, &resp)
+		if err != nil {
+			return nil, err
+		}
+		settings = resp.QueryQuotaUserSettings
+	}
+
+	limits := map[string]QueryQuotaLimits{}
+	for _, setting := range settings {
+		value := 0
+		if setting.ValueKind == queryQuotaValueLimited && setting.Value != nil {
+			value = *setting.Value
+		}
+		intervalLimits := limits[setting.Interval]
+		switch setting.MeasurementKind {
+		case queryQuotaMeasurementStaticCost:
+			intervalLimits.MaxQueryCost = value
+		case queryQuotaMeasurementLiveCost:
+			intervalLimits.MaxLiveQueryCost = value
+		default:
+			continue
+		}
+		limits[setting.Interval] = intervalLimits
+	}
+	return limits, nil
+}
+
+// setLimits replaces all settings of the user, or of the default quota if username is empty
+func (q *QueryQuotas) setLimits(username string, limits map[string]QueryQuotaLimits) error {
+	intervals := make([]string, 0, len(limits))
+	for interval := range limits {
+		intervals = append(intervals, interval)
+	}
+	sort.Strings(intervals)
+	settings := make([]map[string]interface{}, 0, 2*len(intervals))
+	for _, interval := range intervals {
+		settings = append(settings,
+			queryQuotaSettingInput(interval, queryQuotaMeasurementStaticCost, limits[interval].MaxQueryCost),
+			queryQuotaSettingInput(interval, queryQuotaMeasurementLiveCost, limits[interval].MaxLiveQueryCost),
+		)
+	}
+
+	if username == "" {
+		return q.client.Query(context.Background(), setDefaultQueryQuotaMutation, map[string]interface{}{
+			"Settings": settings,
+		}, nil)
+	}
+	return q.client.Query(context.Background(), setUserQueryQuotaMutation, map[string]interface{}{
+		"Username": username,
+		"Settings": settings,
+	}, nil)
+}
+
+func queryQuotaSettingInput(interval, measurementKind string, value int) map[string]interface{} {
+	if value <= 0 {
+		return map[string]interface{}{
+			"interval":        interval,
+			"measurementKind": measurementKind,
+			"valueKind":       queryQuotaValueUnlimited,
+		}
+	}
+	return map[string]interface{}{
+		"interval":        interval,
+		"measurementKind": measurementKind,
+		"value":           value,
+		"valueKind":       queryQuotaValueLimited,
+	}
+}
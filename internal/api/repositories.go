@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"fmt"
 )
 
 // Repository represents a Humio repository
@@ -10,6 +11,23 @@ type Repository struct {
 	Name          string
 	Description   string
 	RetentionDays float64
+	IngestSizeGB  float64
+	StorageSizeGB float64
+}
+
+// S3 archiving format constants
+const (
+	S3ArchivingFormatNDJSON = "NDJSON"
+	S3ArchivingFormatRaw    = "RAW"
+)
+
+// S3ArchivingConfiguration represents the S3 archiving configuration of a Humio repository
+type S3ArchivingConfiguration struct {
+	Bucket    string
+	Region    string
+	Format    string
+	StartFrom string
+	Enabled   bool
 }
 
 // Repositories provides operations for managing repositories
@@ -24,6 +42,8 @@ query GetRepository($RepositoryName: String!) {
     name
     description
     timeBasedRetention
+    ingestSizeBasedRetention
+    storageSizeBasedRetention
   }
 }
 `
@@ -44,23 +64,42 @@ mutation UpdateDescription($RepositoryName: String!, $Description: String!) {
 }
 `
 
-const updateTimeBasedRetentionMutation = `
-mutation UpdateTimeBasedRetention($RepositoryName: String!, $RetentionDays: Float) {
+const updateRetentionMutation = `
+mutation UpdateRetention(
+  $RepositoryName: String!
+  $RetentionDays: Float
+  $IngestSizeGB: Float
+  $StorageSizeGB: Float
+) {
   updateRetention(
     repositoryName: $RepositoryName
     timeBasedRetention: $RetentionDays
+    ingestSizeBasedRetention: $IngestSizeGB
+    storageSizeBasedRetention: $StorageSizeGB
   ) {
     repository {
       id
       name
       ... on Repository {
         timeBasedRetention
+        ingestSizeBasedRetention
+        storageSizeBasedRetention
       }
     }
   }
 }
 `
 
+const retentionLimitQuery = `
+query RetentionLimit {
+  organization {
+    limits {
+      retention
+    }
+  }
+}
+`
+
 const deleteRepositoryMutation = `
 mutation DeleteRepository($RepositoryName: String!, $Reason: String) {
   deleteSearchDomain(name: $RepositoryName, deleteMessage: $Reason) {
@@ -69,11 +108,73 @@ mutation DeleteRepository($RepositoryName: String!, $Reason: String) {
 }
 `
 
+const getS3ArchivingQuery = `
+query GetS3Archiving($RepositoryName: String!) {
+  repository(name: $RepositoryName) {
+    s3ArchivingConfiguration {
+      bucket
+      region
+      format
+      startFrom
+      disabled
+    }
+  }
+}
+`
+
+const configureS3ArchivingMutation = `
+mutation ConfigureS3Archiving(
+  $RepositoryName: String!
+  $Bucket: String!
+  $Region: String!
+  $Format: S3ArchivingFormat!
+  $StartFrom: DateTime
+) {
+  s3ConfigureArchiving(
+    repositoryName: $RepositoryName
+    bucket: $Bucket
+    region: $Region
+    format: $Format
+    startFromDateTime: $StartFrom
+  ) {
+    __typename
+  }
+}
+`
+
+const enableS3ArchivingMutation = `
+mutation EnableS3Archiving($RepositoryName: String!) {
+  s3EnableArchiving(repositoryName: $RepositoryName) {
+    __typename
+  }
+}
+`
+
+const disableS3ArchivingMutation = `
+mutation DisableS3Archiving($RepositoryName: String!) {
+  s3DisableArchiving(repositoryName: $RepositoryName) {
+    __typename
+  }
+}
+`
+
+const resetS3ArchivingMutation = `
+mutation ResetS3Archiving($RepositoryName: String!) {
+  s3ResetArchiving(repositoryName: $RepositoryName) {
+    __typename
+  }
+}
+`
+
 const listRepositoriesQuery = `
 query ListRepositories {
   repositories {
     id
     name
+    description
+    timeBasedRetention
+    ingestSizeBasedRetention
+    storageSizeBasedRetention
   }
 }
 `
@@ -81,18 +182,46 @@ query ListRepositories {
 // listRepositoriesResponse represents the response from list repositories query
 type listRepositoriesResponse struct {
 	Repositories []struct {
-		ID   string `json:"id"`
-		Name string `json:"name"`
+		ID                        string   `json:"id"`
+		Name                      string   `json:"name"`
+		Description               string   `json:"description"`
+		TimeBasedRetention        *float64 `json:"timeBasedRetention"`
+		IngestSizeBasedRetention  *float64 `json:"ingestSizeBasedRetention"`
+		StorageSizeBasedRetention *float64 `json:"storageSizeBasedRetention"`
 	} `json:"repositories"`
 }
 
 // getRepositoryResponse represents the response from get repository query
 type getRepositoryResponse struct {
 	Repository struct {
-		ID                 string   `json:"id"`
-		Name               string   `json:"name"`
-		Description        string   `json:"description"`
-		TimeBasedRetention *float64 `json:"timeBasedRetention"`
+		ID                        string   `json:"id"`
+		Name                      string   `json:"name"`
+		Description               string   `json:"description"`
+		TimeBasedRetention        *float64 `json:"timeBasedRetention"`
+		IngestSizeBasedRetention  *float64 `json:"ingestSizeBasedRetention"`
+		StorageSizeBasedRetention *float64 `json:"storageSizeBasedRetention"`
+	} `json:"repository"`
+}
+
+// retentionLimitResponse represents the response from retention limit query
+type retentionLimitResponse struct {
+	Organization struct {
+		Limits struct {
+			Retention *float64 `json:"retention"`
+		} `json:"limits"`
+	} `json:"organization"`
+}
+
+// getS3ArchivingResponse represents the response from get S3 archiving query
+type getS3ArchivingResponse struct {
+	Repository struct {
+		S3ArchivingConfiguration *struct {
+			Bucket    string  `json:"bucket"`
+			Region    string  `json:"region"`
+			Format    string  `json:"format"`
+			StartFrom *string `json:"startFrom"`
+			Disabled  bool    `json:"disabled"`
+		} `json:"s3ArchivingConfiguration"`
 	} `json:"repository"`
 }
 
@@ -107,8 +236,12 @@ func (r *Repositories) List() ([]Repository, error) {
 	repositories := make([]Repository, len(resp.Repositories))
 	for i, repo := range resp.Repositories {
 		repositories[i] = Repository{
-			ID:   repo.ID,
-			Name: repo.Name,
+			ID:            repo.ID,
+			Name:          repo.Name,
+			Description:   repo.Description,
+			RetentionDays: valueOrZero(repo.TimeBasedRetention),
+			IngestSizeGB:  valueOrZero(repo.IngestSizeBasedRetention),
+			StorageSizeGB: valueOrZero(repo.StorageSizeBasedRetention),
 		}
 	}
 	return repositories, nil
@@ -124,16 +257,13 @@ func (r *Repositories) Get(name string) (Repository, error) {
 		return Repository{}, err
 	}
 
-	retentionDays := 0.0
-	if resp.Repository.TimeBasedRetention != nil {
-		retentionDays = *resp.Repository.TimeBasedRetention
-	}
-
 	return Repository{
 		ID:            resp.Repository.ID,
 		Name:          resp.Repository.Name,
 		Description:   resp.Repository.Description,
-		RetentionDays: retentionDays,
+		RetentionDays: valueOrZero(resp.Repository.TimeBasedRetention),
+		IngestSizeGB:  valueOrZero(resp.Repository.IngestSizeBasedRetention),
+		StorageSizeGB: valueOrZero(resp.Repository.StorageSizeBasedRetention),
 	}, nil
 }
 
@@ -152,8 +282,8 @@ func (r *Repositories) UpdateDescription(name, description string) error {
 	}, nil)
 }
 
-// UpdateTimeBasedRetention updates the time-based retention for a repository
-func (r *Repositories) UpdateTimeBasedRetention(name string, retentionDays float64) error {
+// UpdateRetention updates the time-based and size-based retention for a repository
+func (r *Repositories) UpdateRetention(name string, retentionDays, ingestSizeGB, storageSizeGB float64) error {
 	variables := map[string]interface{}{
 		"RepositoryName": name,
 	}
@@ -162,8 +292,24 @@ func (r *Repositories) UpdateTimeBasedRetention(name string, retentionDays float
 	if retentionDays > 0 {
 		variables["RetentionDays"] = retentionDays
 	}
+	if ingestSizeGB > 0 {
+		variables["IngestSizeGB"] = ingestSizeGB
+	}
+	if storageSizeGB > 0 {
+		variables["StorageSizeGB"] = storageSizeGB
+	}
 
-	return r.client.Query(context.Background(), updateTimeBasedRetentionMutation, variables, nil)
+	return r.client.Query(context.Background(), updateRetentionMutation, variables, nil)
+}
+
+// MaxRetentionDays returns the longest time-based retention the cluster allows, or 0 if it is unlimited
+func (r *Repositories) MaxRetentionDays() (float64, error) {
+	var resp retentionLimitResponse
+	err := r.client.Query(context.Background(), retentionLimitQuery, nil, &resp)
+	if err != nil {
+		return 0, err
+	}
+	return valueOrZero(resp.Organization.Limits.Retention), nil
 }
 
 // Delete deletes a repository
@@ -173,3 +319,72 @@ func (r *Repositories) Delete(name, reason string) error {
 		"Reason":         reason,
 	}, nil)
 }
+
+// GetS3Archiving returns the S3 archiving configuration of a repository
+func (r *Repositories) GetS3Archiving(name string) (*S3ArchivingConfiguration, error) {
+	var resp getS3ArchivingResponse
+	err := r.client.Query(context.Background(), getS3ArchivingQuery, map[string]interface{}{
+		"RepositoryName": name,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	rawConfig := resp.Repository.S3ArchivingConfiguration
+	if rawConfig == nil {
+		return nil, fmt.Errorf("S3 archiving is not configured for repository: %s", name)
+	}
+
+	startFrom := ""
+	if rawConfig.StartFrom != nil {
+		startFrom = *rawConfig.StartFrom
+	}
+
+	return &S3ArchivingConfiguration{
+		Bucket:    rawConfig.Bucket,
+		Region:    rawConfig.Region,
+		Format:    rawConfig.Format,
+		StartFrom: startFrom,
+		Enabled:   !rawConfig.Disabled,
+	}, nil
+}
+
+// ConfigureS3Archiving sets the S3 archiving configuration of a repository and enables or disables archiving
+func (r *Repositories) ConfigureS3Archiving(name string, config *S3ArchivingConfiguration) error {
+	variables := map[string]interface{}{
+		"RepositoryName": name,
+		"Bucket":         config.Bucket,
+		"Region":         config.Region,
+		"Format":         config.Format,
+	}
+	if config.StartFrom != "" {
+		variables["StartFrom"] = config.StartFrom
+	}
+
+	err := r.client.Query(context.Background(), configureS3ArchivingMutation, variables, nil)
+	if err != nil {
+		return err
+	}
+
+	toggleMutation := disableS3ArchivingMutation
+	if config.Enabled {
+		toggleMutation = enableS3ArchivingMutation
+	}
+	return r.client.Query(context.Background(), toggleMutation, map[string]interface{}{
+		"RepositoryName": name,
+	}, nil)
+}
+
+// ResetS3Archiving removes the S3 archiving configuration of a repository
+func (r *Repositories) ResetS3Archiving(name string) error {
+	return r.client.Query(context.Background(), resetS3ArchivingMutation, map[string]interface{}{
+		"RepositoryName": name,
+	}, nil)
+}
+
+func valueOrZero(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
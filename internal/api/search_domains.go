@@ -0,0 +1,253 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// defaultSavedQueryName is the start of the name of the saved queries created to hold the default query of a search
+// domain. It is made unique, so it never matches a saved query created by a user.
+const defaultSavedQueryName = "Default query managed by Terraform"
+
+// SearchDomainSettings represents the per-domain settings of a repository or view. The description is managed by the
+// repository itself, and deliberately not part of these settings.
+type SearchDomainSettings struct {
+	SearchDomain    string
+	AutomaticSearch bool
+	// DefaultQueryID is the ID of the saved query holding DefaultQuery and DefaultTimeWindow, or empty if there is
+	// none. Both being empty means the search domain has no default query.
+	DefaultQueryID    string
+	DefaultQuery      string
+	DefaultTimeWindow string
+}
+
+// SearchDomains provides operations for managing the settings of repositories and views
+type SearchDomains struct {
+	client *Client
+}
+
+const getSearchDomainSettingsQuery = `
+query GetSearchDomainSettings($SearchDomainName: String!) {
+  searchDomain(name: $SearchDomainName) {
+    automaticSearch
+    savedQueries {
+      id
+    }
+    defaultQuery {
+      id
+      query {
+        queryString
+        start
+      }
+    }
+  }
+}
+`
+
+const setAutomaticSearchingMutation = `
+mutation SetAutomaticSearching($SearchDomainName: String!, $AutomaticSearch: Boolean!) {
+  setAutomaticSearching(name: $SearchDomainName, automaticSearch: $AutomaticSearch) {
+    __typename
+  }
+}
+`
+
+const createSavedQueryMutation = `
+mutation CreateSavedQuery($SearchDomainName: String!, $Name: String!, $QueryString: String!, $Start: String) {
+  createSavedQuery(input: {
+    viewName: $SearchDomainName
+    name: $Name
+    queryString: $QueryString
+    start: $Start
+  }) {
+    savedQuery {
+      id
+    }
+  }
+}
+`
+
+const updateSavedQueryMutation = `
+mutation UpdateSavedQuery($SearchDomainName: String!, $ID: String!, $QueryString: String!, $Start: String) {
+  updateSavedQuery(input: {
+    viewName: $SearchDomainName
+    id: $ID
+    queryString: $QueryString
+    start: $Start
+  }) {
+    savedQuery {
+      id
+    }
+  }
+}
+`
+
+const deleteSavedQueryMutation = `
+mutation DeleteSavedQuery($SearchDomainName: String!, $ID: String!) {
+  deleteSavedQuery(input: {
+    viewName: $SearchDomainName
+    id: $ID
+  })
+}
+`
+
+const setDefaultSavedQueryMutation = `
+mutation SetDefaultSavedQuery($SearchDomainName: String!, $SavedQueryID: String) {
+  setDefaultSavedQuery(input: {
+    viewName: $SearchDomainName
+    savedQueryId: $SavedQueryID
+  }) {
+    __typename
+  }
+}
+`
+
+// getSearchDomainSettingsResponse represents the response from get search domain settings query
+type getSearchDomainSettingsResponse struct {
+	SearchDomain struct {
+		AutomaticSearch bool `json:"automaticSearch"`
+		SavedQueries    []struct {
+			ID string `json:"id"`
+		} `json:"savedQueries"`
+		DefaultQuery *struct {
+			ID    string `json:"id"`
+			Query struct {
+				QueryString string `json:"queryString"`
+				Start       string `json:"start"`
+			} `json:"query"`
+		} `json:"defaultQuery"`
+	} `json:"searchDomain"`
+}
+
+// createSavedQueryResponse represents the response from create saved query mutation
+type createSavedQueryResponse struct {
+	CreateSavedQuery struct {
+		SavedQuery struct {
+			ID string `json:"id"`
+		} `json:"savedQuery"`
+	} `json:"createSavedQuery"`
+}
+
+func (s *SearchDomains) getSettings(searchDomain string) (*getSearchDomainSettingsResponse, error) {
+	var resp getSearchDomainSettingsResponse
+	err := s.client.Query(context.Background(), getSearchDomainSettingsQuery, map[string]interface{}{
+		"SearchDomainName": searchDomain,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetSettings returns the settings of a repository or view
+func (s *SearchDomains) GetSettings(searchDomain string) (*SearchDomainSettings, error) {
+	resp, err := s.getSettings(searchDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &SearchDomainSettings{
+		SearchDomain:    searchDomain,
+		AutomaticSearch: resp.SearchDomain.AutomaticSearch,
+	}
+	if resp.SearchDomain.DefaultQuery != nil {
+		settings.DefaultQueryID = resp.SearchDomain.DefaultQuery.ID
+		settings.DefaultQuery = resp.SearchDomain.DefaultQuery.Query.QueryString
+		settings.DefaultTimeWindow = resp.SearchDomain.DefaultQuery.Query.Start
+	}
+
+	return settings, nil
+}
+
+// UpdateSettings replaces the settings of a repository or view. The default query is stored as a saved query, and
+// settings.DefaultQueryID must be the ID of the one returned by the previous update, if any. Only that saved query is
+// ever updated or deleted; any other default query is replaced as the default, but otherwise left alone. The returned
+// settings hold the ID of the saved query now holding the default query.
+func (s *SearchDomains) UpdateSettings(settings *SearchDomainSettings) (*SearchDomainSettings, error) {
+	err := s.client.Query(context.Background(), setAutomaticSearchingMutation, map[string]interface{}{
+		"SearchDomainName": settings.SearchDomain,
+		"AutomaticSearch":  settings.AutomaticSearch,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.getSettings(settings.SearchDomain)
+	if err != nil {
+		return nil, err
+	}
+	var currentID string
+	if resp.SearchDomain.DefaultQuery != nil {
+		currentID = resp.SearchDomain.DefaultQuery.ID
+	}
+	// The saved query may have been deleted outside of Terraform, in which case a new one is created.
+	var exists bool
+	for _, savedQuery := range resp.SearchDomain.SavedQueries {
+		if settings.DefaultQueryID != "" && savedQuery.ID == settings.DefaultQueryID {
+			exists = true
+		}
+	}
+
+	updated := *settings
+	if settings.DefaultQuery == "" && settings.DefaultTimeWindow == "" {
+		updated.DefaultQueryID = ""
+		if !exists {
+			return &updated, nil
+		}
+		if currentID == settings.DefaultQueryID {
+			err = s.client.Query(context.Background(), setDefaultSavedQueryMutation, map[string]interface{}{
+				"SearchDomainName": settings.SearchDomain,
+				"SavedQueryID":     nil,
+			}, nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+		err = s.client.Query(context.Background(), deleteSavedQueryMutation, map[string]interface{}{
+			"SearchDomainName": settings.SearchDomain,
+			"ID":               settings.DefaultQueryID,
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &updated, nil
+	}
+
+	if exists {
+		err = s.client.Query(context.Background(), updateSavedQueryMutation, map[string]interface{}{
+			"SearchDomainName": settings.SearchDomain,
+			"ID":               settings.DefaultQueryID,
+			"QueryString":      settings.DefaultQuery,
+			"Start":            optionalString(settings.DefaultTimeWindow),
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var createResp createSavedQueryResponse
+		err = s.client.Query(context.Background(), createSavedQueryMutation, map[string]interface{}{
+			"SearchDomainName": settings.SearchDomain,
+			"Name":             fmt.Sprintf("%s %d", defaultSavedQueryName, time.Now().UnixNano()),
+			"QueryString":      settings.DefaultQuery,
+			"Start":            optionalString(settings.DefaultTimeWindow),
+		}, &createResp)
+		if err != nil {
+			return nil, err
+		}
+		updated.DefaultQueryID = createResp.CreateSavedQuery.SavedQuery.ID
+	}
+
+	if currentID != updated.DefaultQueryID {
+		err = s.client.Query(context.Background(), setDefaultSavedQueryMutation, map[string]interface{}{
+			"SearchDomainName": settings.SearchDomain,
+			"SavedQueryID":     updated.DefaultQueryID,
+		}, nil)
+		if err != nil {
+			// The saved query is returned along with the error, so it can be deleted later.
+			return &updated, err
+		}
+	}
+
+	return &updated, nil
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"fmt"
+)
+
+// SharedDashboardLink represents a read-only link giving access to a dashboard without logging in
+type SharedDashboardLink struct {
+	Name        string
+	DashboardID string
+	IPFilterID  string
+	// Token is the secret part of the link URL
+	Token string
+}
+
+// SharedDashboardLinks provides operations for managing shared dashboard links
+type SharedDashboardLinks struct {
+	client *Client
+}
+
+const listSharedDashboardLinksQuery = `
+query ListSharedDashboardLinks($SearchDomainName: String!, $DashboardID: String!) {
+  searchDomain(name: $SearchDomainName) {
+    dashboard(id: $DashboardID) {
+      readOnlyTokens {
+        name
+        token
+        ipFilterV2 {
+          id
+        }
+      }
+    }
+  }
+}
+`
+
+const createSharedDashboardLinkMutation = `
+mutation CreateSharedDashboardLink($DashboardID: String!, $Name: String!, $IPFilterID: String) {
+  createReadonlyToken(id: $DashboardID, name: $Name, ipFilterId: $IPFilterID) {
+    name
+    token
+  }
+}
+`
+
+const deleteSharedDashboardLinkMutation = `
+mutation DeleteSharedDashboardLink($DashboardID: String!, $Token: String!) {
+  deleteReadonlyToken(id: $DashboardID, token: $Token) {
+    __typename
+  }
+}
+`
+
+// listSharedDashboardLinksResponse represents the response from list shared dashboard links query
+type listSharedDashboardLinksResponse struct {
+	SearchDomain struct {
+		Dashboard struct {
+			ReadOnlyTokens []struct {
+				Name       string `json:"name"`
+				Token      string `json:"token"`
+				IPFilterV2 *struct {
+					ID string `json:"id"`
+				} `json:"ipFilterV2"`
+			} `json:"readOnlyTokens"`
+		} `json:"dashboard"`
+	} `json:"searchDomain"`
+}
+
+// createSharedDashboardLinkResponse represents the response from create shared dashboard link mutation
+type createSharedDashboardLinkResponse struct {
+	CreateReadonlyToken struct {
+		Name  string `json:"name"`
+		Token string `json:"token"`
+	} `json:"createReadonlyToken"`
+}
+
+// List returns all shared links for the given dashboard
+func (l *SharedDashboardLinks) List(viewName, dashboardID string) ([]SharedDashboardLink, error) {
+	var resp listSharedDashboardLinksResponse
+	err := l.client.Query(context.Background(), listSharedDashboardLinksQuery, map[string]interface{}{
+		"SearchDomainName": viewName,
+		"DashboardID":      dashboardID,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]SharedDashboardLink, len(resp.SearchDomain.Dashboard.ReadOnlyTokens))
+	for i, link := range resp.SearchDomain.Dashboard.ReadOnlyTokens {
+		ipFilterID := ""
+		if link.IPFilterV2 != nil {
+			ipFilterID = link.IPFilterV2.ID
+		}
+		links[i] = SharedDashboardLink{
+			Name:        link.Name,
+			DashboardID: dashboardID,
+			IPFilterID:  ipFilterID,
+			Token:       link.Token,
+		}
+	}
+
+	return links, nil
+}
+
+// Get returns a shared dashboard link by name
+func (l *SharedDashboardLinks) Get(viewName, dashboardID, name string) (*SharedDashboardLink, error) {
+	links, err := l.List(viewName, dashboardID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, link := range links {
+		if link.Name == name {
+			return &link, nil
+		}
+	}
+
+	return nil, fmt.Errorf("shared dashboard link not found: %s", name)
+}
+
+// Add creates a new shared dashboard link
+func (l *SharedDashboardLinks) Add(link *SharedDashboardLink) (*SharedDashboardLink, error) {
+	variables := map[string]interface{}{
+		"DashboardID": link.DashboardID,
+		"Name":        link.Name,
+	}
+	if link.IPFilterID != "" {
+		variables["IPFilterID"] = link.IPFilterID
+	}
+
+	var resp createSharedDashboardLinkResponse
+	err := l.client.Query(context.Background(), createSharedDashboardLinkMutation, variables, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	link.Token = resp.CreateReadonlyToken.Token
+	return link, nil
+}
+
+// Delete revokes a shared dashboard link by its token
+func (l *SharedDashboardLinks) Delete(dashboardID, token string) error {
+	return l.client.Query(context.Background(), deleteSharedDashboardLinkMutation, map[string]interface{}{
+		"DashboardID": dashboardID,
+		"Token":       token,
+	}, nil)
+}